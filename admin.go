@@ -0,0 +1,227 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+type PRINTER_DEFAULTS struct {
+	Datatype      *uint16
+	DevMode       uintptr
+	DesiredAccess uint32
+}
+
+type PRINTER_INFO_2 struct {
+	ServerName         *uint16
+	PrinterName        *uint16
+	ShareName          *uint16
+	PortName           *uint16
+	DriverName         *uint16
+	Comment            *uint16
+	Location           *uint16
+	DevMode            uintptr
+	SepFile            *uint16
+	PrintProcessor     *uint16
+	Datatype           *uint16
+	Parameters         *uint16
+	SecurityDescriptor uintptr
+	Attributes         uint32
+	Priority           uint32
+	DefaultPriority    uint32
+	StartTime          uint32
+	UntilTime          uint32
+	Status             uint32
+	Jobs               uint32
+	AveragePPM         uint32
+}
+
+const (
+	PRINTER_ACCESS_ADMINISTER = 0x00000004
+	PRINTER_ACCESS_USE        = 0x00000008
+	PRINTER_ALL_ACCESS        = 0x000F000C
+)
+
+const (
+	PRINTER_ATTRIBUTE_QUEUED            = 0x00000001
+	PRINTER_ATTRIBUTE_DIRECT            = 0x00000002
+	PRINTER_ATTRIBUTE_DEFAULT           = 0x00000004
+	PRINTER_ATTRIBUTE_SHARED            = 0x00000008
+	PRINTER_ATTRIBUTE_NETWORK           = 0x00000010
+	PRINTER_ATTRIBUTE_HIDDEN            = 0x00000020
+	PRINTER_ATTRIBUTE_LOCAL             = 0x00000040
+	PRINTER_ATTRIBUTE_ENABLE_DEVQ       = 0x00000080
+	PRINTER_ATTRIBUTE_KEEPPRINTEDJOBS   = 0x00000100
+	PRINTER_ATTRIBUTE_DO_COMPLETE_FIRST = 0x00000200
+	PRINTER_ATTRIBUTE_WORK_OFFLINE      = 0x00000400
+	PRINTER_ATTRIBUTE_ENABLE_BIDI       = 0x00000800
+	PRINTER_ATTRIBUTE_RAW_ONLY          = 0x00001000
+	PRINTER_ATTRIBUTE_PUBLISHED         = 0x00002000
+)
+
+//sys	addPrinter(server *uint16, level uint32, pi *PRINTER_INFO_2) (h syscall.Handle, err error) = winspool.AddPrinterW
+//sys	deletePrinter(h syscall.Handle) (err error) = winspool.DeletePrinter
+//sys	AddPrinterConnection(name *uint16) (err error) = winspool.AddPrinterConnectionW
+//sys	DeletePrinterConnection(name *uint16) (err error) = winspool.DeletePrinterConnectionW
+//sys	GetPrinter(h syscall.Handle, level uint32, buf *byte, bufN uint32, needed *uint32) (err error) = winspool.GetPrinterW
+//sys	SetPrinter(h syscall.Handle, level uint32, buf *byte, command uint32) (err error) = winspool.SetPrinterW
+
+// PrinterConfig describes printer queue configuration.
+type PrinterConfig struct {
+	Name           string
+	ShareName      string // used when Attributes has PRINTER_ATTRIBUTE_SHARED set
+	PortName       string
+	DriverName     string
+	Comment        string
+	Location       string
+	PrintProcessor string // "winprint", if empty
+	Datatype       string // "RAW", if empty
+	Attributes     uint32 // combination of PRINTER_ATTRIBUTE_* flags
+}
+
+// stringToUTF16Ptr is syscall.StringToUTF16Ptr that
+// returns nil for empty strings.
+func stringToUTF16Ptr(s string) *uint16 {
+	if s == "" {
+		return nil
+	}
+	return syscall.StringToUTF16Ptr(s)
+}
+
+// OpenWithAccess opens printer name requesting access rights. Use
+// PRINTER_ALL_ACCESS to be able to change printer configuration.
+func OpenWithAccess(name string, access uint32) (*Printer, error) {
+	var p Printer
+	d := PRINTER_DEFAULTS{DesiredAccess: access}
+	err := OpenPrinter(&(syscall.StringToUTF16(name))[0], &p.h, uintptr(unsafe.Pointer(&d)))
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// AddPrinter creates new printer queue described by c.
+// Printer driver and port must be installed already.
+// Returned printer must be closed with Close. CUPS printer
+// queues are managed with ipp.Client AddPrinter and DeletePrinter.
+func AddPrinter(c PrinterConfig) (*Printer, error) {
+	if c.PrintProcessor == "" {
+		c.PrintProcessor = "winprint"
+	}
+	if c.Datatype == "" {
+		c.Datatype = "RAW"
+	}
+	pi := PRINTER_INFO_2{
+		PrinterName:    stringToUTF16Ptr(c.Name),
+		ShareName:      stringToUTF16Ptr(c.ShareName),
+		PortName:       stringToUTF16Ptr(c.PortName),
+		DriverName:     stringToUTF16Ptr(c.DriverName),
+		Comment:        stringToUTF16Ptr(c.Comment),
+		Location:       stringToUTF16Ptr(c.Location),
+		PrintProcessor: stringToUTF16Ptr(c.PrintProcessor),
+		Datatype:       stringToUTF16Ptr(c.Datatype),
+		Attributes:     c.Attributes,
+	}
+	h, err := addPrinter(nil, 2, &pi)
+	if err != nil {
+		return nil, err
+	}
+	return &Printer{h: h}, nil
+}
+
+// DeletePrinter deletes printer queue name. If the queue still has
+// print jobs, Windows only marks the printer as pending deletion,
+// and removes it after all its jobs complete. Delete the jobs
+// first to remove the printer immediately.
+func DeletePrinter(name string) error {
+	p, err := OpenWithAccess(name, PRINTER_ALL_ACCESS)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	return deletePrinter(p.h)
+}
+
+// AddConnection connects to network printer name, like `\\server\share`,
+// for current user.
+func AddConnection(name string) error {
+	return AddPrinterConnection(&(syscall.StringToUTF16(name))[0])
+}
+
+// DeleteConnection removes network printer connection name.
+func DeleteConnection(name string) error {
+	return DeletePrinterConnection(&(syscall.StringToUTF16(name))[0])
+}
+
+// getPrinter2 returns PRINTER_INFO_2 of printer p and buffer
+// that holds all strings PRINTER_INFO_2 points to.
+func (p *Printer) getPrinter2() (*PRINTER_INFO_2, []byte, error) {
+	var needed uint32
+	buf := make([]byte, 1)
+	for {
+		err := GetPrinter(p.h, 2, &buf[0], uint32(len(buf)), &needed)
+		if err == nil {
+			break
+		}
+		if err != syscall.ERROR_INSUFFICIENT_BUFFER {
+			return nil, nil, err
+		}
+		if needed <= uint32(len(buf)) {
+			return nil, nil, err
+		}
+		buf = make([]byte, needed)
+	}
+	return (*PRINTER_INFO_2)(unsafe.Pointer(&buf[0])), buf, nil
+}
+
+// Config returns printer p configuration.
+func (p *Printer) Config() (*PrinterConfig, error) {
+	pi, _, err := p.getPrinter2()
+	if err != nil {
+		return nil, err
+	}
+	return &PrinterConfig{
+		Name:           utf16PtrToString(pi.PrinterName),
+		ShareName:      utf16PtrToString(pi.ShareName),
+		PortName:       utf16PtrToString(pi.PortName),
+		DriverName:     utf16PtrToString(pi.DriverName),
+		Comment:        utf16PtrToString(pi.Comment),
+		Location:       utf16PtrToString(pi.Location),
+		PrintProcessor: utf16PtrToString(pi.PrintProcessor),
+		Datatype:       utf16PtrToString(pi.Datatype),
+		Attributes:     pi.Attributes,
+	}, nil
+}
+
+// SetPrinter replaces printer p configuration with c. Use Config to
+// obtain current configuration and change fields as required.
+// Printer p must be opened with PRINTER_ALL_ACCESS (see OpenWithAccess).
+func (p *Printer) SetPrinter(c *PrinterConfig) error {
+	old, buf, err := p.getPrinter2()
+	if err != nil {
+		return err
+	}
+	pi := *old
+	pi.PrinterName = stringToUTF16Ptr(c.Name)
+	pi.ShareName = stringToUTF16Ptr(c.ShareName)
+	pi.PortName = stringToUTF16Ptr(c.PortName)
+	pi.DriverName = stringToUTF16Ptr(c.DriverName)
+	pi.Comment = stringToUTF16Ptr(c.Comment)
+	pi.Location = stringToUTF16Ptr(c.Location)
+	pi.PrintProcessor = stringToUTF16Ptr(c.PrintProcessor)
+	pi.Datatype = stringToUTF16Ptr(c.Datatype)
+	pi.Attributes = c.Attributes
+	// Leave security descriptor alone.
+	pi.SecurityDescriptor = 0
+	err = SetPrinter(p.h, 2, (*byte)(unsafe.Pointer(&pi)), 0)
+	// pi.DevMode and other fields still point into buf.
+	runtime.KeepAlive(buf)
+	return err
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestAddDeletePrinter(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	di, err := p.DriverInfo()
+	p.Close()
+	if err != nil {
+		t.Fatalf("DriverInfo failed: %v", err)
+	}
+
+	const testName = "printer package test printer"
+	p, err = AddPrinter(PrinterConfig{
+		Name:       testName,
+		PortName:   "FILE:",
+		DriverName: di.Name,
+		Comment:    "created by TestAddDeletePrinter",
+	})
+	if err == syscall.ERROR_ACCESS_DENIED {
+		t.Skip("AddPrinter requires administrator rights")
+	}
+	if err != nil {
+		t.Fatalf("AddPrinter failed: %v", err)
+	}
+	defer func() {
+		err := DeletePrinter(testName)
+		if err != nil {
+			t.Errorf("DeletePrinter failed: %v", err)
+		}
+	}()
+	defer p.Close()
+
+	c, err := p.Config()
+	if err != nil {
+		t.Fatalf("Config failed: %v", err)
+	}
+	if c.Name != testName || c.PortName != "FILE:" || c.DriverName != di.Name {
+		t.Fatalf("unexpected printer config: %+v", c)
+	}
+	c.Location = "test lab"
+	err = p.SetPrinter(c)
+	if err != nil {
+		t.Fatalf("SetPrinter failed: %v", err)
+	}
+	c, err = p.Config()
+	if err != nil {
+		t.Fatalf("Config failed: %v", err)
+	}
+	if c.Location != "test lab" {
+		t.Fatalf("printer location is %q, want %q", c.Location, "test lab")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package barcode encodes data as 1D and 2D barcodes, so barcodes can
+// be printed with any page description language, and not only with
+// printer built-in barcode fonts.
+//
+// Encoded barcode is returned as Code, a grid of modules. 1D barcodes
+// are one module high. Code can be drawn as a bitmap with Image, or as
+// a list of black rectangles with Bars. Quiet zone around the barcode
+// is not part of Code and must be left blank by the caller.
+package barcode
+
+import (
+	"errors"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+var (
+	ErrData     = errors.New("barcode: data cannot be encoded")
+	ErrTooLarge = errors.New("barcode: data too large")
+)
+
+// Code is encoded barcode.
+type Code struct {
+	Width, Height int // size in modules
+	modules       []bool
+}
+
+func newCode(width, height int) *Code {
+	return &Code{
+		Width:   width,
+		Height:  height,
+		modules: make([]bool, width*height),
+	}
+}
+
+// newLinear returns 1D barcode with modules.
+func newLinear(modules []bool) *Code {
+	return &Code{
+		Width:   len(modules),
+		Height:  1,
+		modules: modules,
+	}
+}
+
+// Black reports whether module x, y is black.
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Width || y >= c.Height {
+		return false
+	}
+	return c.modules[y*c.Width+x]
+}
+
+func (c *Code) set(x, y int, black bool) {
+	c.modules[y*c.Width+x] = black
+}
+
+// Bar is black rectangle of barcode. Position and size are in modules.
+type Bar struct {
+	X, Y, Width, Height int
+}
+
+// Bars returns barcode as list of black rectangles, one for every
+// run of black modules in a row. Vector formats can draw the bars
+// scaled to the required module size.
+func (c *Code) Bars() []Bar {
+	var bars []Bar
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; {
+			if !c.Black(x, y) {
+				x++
+				continue
+			}
+			start := x
+			for x < c.Width && c.Black(x, y) {
+				x++
+			}
+			bars = append(bars, Bar{X: start, Y: y, Width: x - start, Height: 1})
+		}
+	}
+	return bars
+}
+
+// Image returns barcode drawn with modules of width by height pixels.
+// 1D barcodes are one module high, so height is height of the bars.
+func (c *Code) Image(width, height int) *bitmap.Bitmap {
+	b := bitmap.New(c.Width*width, c.Height*height)
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if c.Black(x/width, y/height) {
+				b.Set(x, y, true)
+			}
+		}
+	}
+	return b
+}
+
+// widths appends to modules elements of widths alternating black and
+// white, starting with black.
+func widths(modules []bool, w ...int) []bool {
+	for i, n := range w {
+		for ; n > 0; n-- {
+			modules = append(modules, i%2 == 0)
+		}
+	}
+	return modules
+}
+
+// digits returns decimal digits of s, or false if s contains other
+// characters.
+func digits(s string) ([]int, bool) {
+	d := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return nil, false
+		}
+		d[i] = int(s[i] - '0')
+	}
+	return d, true
+}
@@ -0,0 +1,428 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// modules returns row y of c as string of '#' and '.'.
+func modules(c *Code, y int) string {
+	var b strings.Builder
+	for x := 0; x < c.Width; x++ {
+		if c.Black(x, y) {
+			b.WriteByte('#')
+		} else {
+			b.WriteByte('.')
+		}
+	}
+	return b.String()
+}
+
+// linear returns modules of 1D code c as string of '1' and '0'.
+func linear(c *Code) string {
+	s := modules(c, 0)
+	return strings.NewReplacer("#", "1", ".", "0").Replace(s)
+}
+
+func TestLinear(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func() (*Code, error)
+		want string
+	}{
+		{
+			name: "code128",
+			f:    func() (*Code, error) { return Code128("HELLO") },
+			want: "110100100001100010100010001101000100011011101000110111010001110110110001010001100011101011",
+		},
+		{
+			name: "code128 C",
+			f:    func() (*Code, error) { return Code128("12345678") },
+			want: "1101001110010110011100100010110001110001011011000010100100011101101100011101011",
+		},
+		{
+			name: "code39",
+			f:    func() (*Code, error) { return Code39("A", false) },
+			want: "10001011101110101110101000101110100010111011101",
+		},
+		{
+			name: "ean13",
+			f:    func() (*Code, error) { return EAN13("400638133393") },
+			want: "10100011010100111010111101111010001001011001101010100001010000101000010111010010000101100110101",
+		},
+		{
+			name: "itf",
+			f:    func() (*Code, error) { return ITF("1234", false) },
+			want: "101011101000101011100011101110100010100011101",
+		},
+	}
+	for _, tt := range tests {
+		c, err := tt.f()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if c.Height != 1 {
+			t.Errorf("%s: height is %d, want 1", tt.name, c.Height)
+		}
+		if got := linear(c); got != tt.want {
+			t.Errorf("%s:\ngot  %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCode128Sets(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+	}{
+		{"ab", []int{104, 65, 66}},
+		{"\tA", []int{103, 73, 33}},
+		{"12", []int{105, 12}},
+		{"123", []int{104, 17, 18, 19}},
+		{"12345", []int{105, 12, 34, 100, 21}},
+		{"A123456", []int{104, 33, 99, 12, 34, 56}},
+		{"A1234B", []int{104, 33, 17, 18, 19, 20, 34}},
+		{"A1234567B", []int{104, 33, 17, 99, 23, 45, 67, 100, 34}},
+		{"a\nb", []int{104, 65, 101, 74, 100, 66}},
+	}
+	for _, tt := range tests {
+		got, err := code128Symbols(tt.s)
+		if err != nil {
+			t.Errorf("%q: %v", tt.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.s, got, tt.want)
+		}
+	}
+	for _, s := range []string{"", "caf\xe9"} {
+		if _, err := Code128(s); err != ErrData {
+			t.Errorf("%q: got %v, want %v", s, err, ErrData)
+		}
+	}
+}
+
+func TestCheckDigits(t *testing.T) {
+	a, err := EAN13("4006381333931")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := EAN13("400638133393")
+	if linear(a) != linear(b) {
+		t.Error("EAN-13 with and without check digit differ")
+	}
+	if _, err := EAN13("4006381333932"); err != ErrData {
+		t.Errorf("wrong check digit: got %v, want %v", err, ErrData)
+	}
+	u, err := UPCA("03600029145")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, _ := EAN13("0036000291452")
+	if linear(u) != linear(e) {
+		t.Error("UPC-A differs from EAN-13 with leading zero")
+	}
+	if _, err := ITF("123", false); err != ErrData {
+		t.Errorf("ITF of odd digits: got %v, want %v", err, ErrData)
+	}
+	i1, _ := ITF("123", true)
+	i2, _ := ITF("1236", false)
+	if linear(i1) != linear(i2) {
+		t.Error("ITF check digit is wrong")
+	}
+	c1, _ := Code39("AB", true)
+	c2, _ := Code39("ABL", false)
+	if linear(c1) != linear(c2) {
+		t.Error("Code 39 check character is wrong")
+	}
+	if _, err := Code39("a", false); err != ErrData {
+		t.Errorf("Code 39 of small letter: got %v, want %v", err, ErrData)
+	}
+}
+
+func TestQR(t *testing.T) {
+	c, err := QR("HELLO WORLD", QRLevelM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"#######...#.#.#######",
+		"#.....#.###...#.....#",
+		"#.###.#...#.#.#.###.#",
+		"#.###.#...#.#.#.###.#",
+		"#.###.#.#.###.#.###.#",
+		"#.....#..###..#.....#",
+		"#######.#.#.#.#######",
+		".....................",
+		"#.#.#.#..#..#...#..#.",
+		".####...#..#....#...#",
+		"...#######.#..#.##...",
+		"####.#.##..###.#.###.",
+		".#..####.#.#..###.#.#",
+		"........#.#...#...#.#",
+		"#######.....#..#.##..",
+		"#.....#..##...##.#...",
+		"#.###.#.##..#.#######",
+		"#.###.#...##.#.#...#.",
+		"#.###.#.####.###.#..#",
+		"#.....#....###...#.##",
+		"#######.##.#.###....#",
+	}
+	if c.Width != 21 || c.Height != 21 {
+		t.Fatalf("size is %dx%d, want 21x21", c.Width, c.Height)
+	}
+	for y, w := range want {
+		if got := modules(c, y); got != w {
+			t.Errorf("row %d:\ngot  %s\nwant %s", y, got, w)
+		}
+	}
+}
+
+func TestQRVersion(t *testing.T) {
+	tests := []struct {
+		s     string
+		level QRLevel
+		size  int
+	}{
+		{strings.Repeat("1", 41), QRLevelL, 21},
+		{strings.Repeat("1", 42), QRLevelL, 25},
+		{strings.Repeat("A", 25), QRLevelL, 21},
+		{strings.Repeat("a", 17), QRLevelL, 21},
+		{strings.Repeat("a", 7), QRLevelH, 21},
+		{strings.Repeat("a", 8), QRLevelH, 25},
+		{strings.Repeat("a", 2953), QRLevelL, 177},
+	}
+	for _, tt := range tests {
+		c, err := QR(tt.s, tt.level)
+		if err != nil {
+			t.Errorf("%d %c: %v", len(tt.s), tt.level, err)
+			continue
+		}
+		if c.Width != tt.size {
+			t.Errorf("%d %c: size is %d, want %d", len(tt.s), tt.level, c.Width, tt.size)
+		}
+	}
+	if _, err := QR(strings.Repeat("a", 2954), QRLevelL); err != ErrTooLarge {
+		t.Errorf("got %v, want %v", err, ErrTooLarge)
+	}
+}
+
+func TestDataMatrix(t *testing.T) {
+	c, err := DataMatrix([]byte("A"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"#.#.#.#.#.",
+		"##.##...##",
+		"#...##.#..",
+		"#..##.#.##",
+		"#..#.#....",
+		"#..#..#.##",
+		"##.#..##..",
+		"##..####.#",
+		"##....#...",
+		"##########",
+	}
+	if c.Width != 10 || c.Height != 10 {
+		t.Fatalf("size is %dx%d, want 10x10", c.Width, c.Height)
+	}
+	for y, w := range want {
+		if got := modules(c, y); got != w {
+			t.Errorf("row %d:\ngot  %s\nwant %s", y, got, w)
+		}
+	}
+
+	for _, tt := range []struct{ n, size int }{{3, 10}, {4, 12}, {8, 14}, {9, 16}, {1558, 144}} {
+		c, err := DataMatrix([]byte(strings.Repeat("A", tt.n)))
+		if err != nil {
+			t.Errorf("%d: %v", tt.n, err)
+			continue
+		}
+		if c.Width != tt.size {
+			t.Errorf("%d: size is %d, want %d", tt.n, c.Width, tt.size)
+		}
+	}
+	if _, err := DataMatrix(make([]byte, 1559)); err != ErrTooLarge {
+		t.Errorf("got %v, want %v", err, ErrTooLarge)
+	}
+}
+
+func TestPDF417Patterns(t *testing.T) {
+	// Every pattern has 4 bars and 4 spaces, and cluster number is
+	// calculated from bar widths.
+	for cluster, patterns := range pdf417Patterns {
+		for v, p := range patterns {
+			var w []int
+			prev := uint32(0)
+			for i := 16; i >= 0; i-- {
+				bit := p >> uint(i) & 1
+				if i == 16 || bit != prev {
+					w = append(w, 0)
+				}
+				w[len(w)-1]++
+				prev = bit
+			}
+			if p>>16 != 1 || len(w) != 8 {
+				t.Fatalf("cluster %d codeword %d: bad pattern %017b", cluster, v, p)
+			}
+			if k := (w[0] - w[2] + w[4] - w[6] + 9) % 9; k != cluster*3 {
+				t.Fatalf("cluster %d codeword %d: pattern of cluster %d", cluster, v, k)
+			}
+		}
+	}
+}
+
+// pdf417Decode returns codewords of every row of c.
+func pdf417Decode(t *testing.T, c *Code) [][]int {
+	var rows [][]int
+	for y := 0; y < c.Height; y++ {
+		cluster := y % 3
+		var row []int
+		for x := 0; x+17 < c.Width; x += 17 {
+			var p uint32
+			for i := 0; i < 17; i++ {
+				p <<= 1
+				if c.Black(x+i, y) {
+					p |= 1
+				}
+			}
+			switch {
+			case x == 0:
+				if p != pdf417Start {
+					t.Fatalf("row %d: bad start pattern", y)
+				}
+			case x+18 == c.Width:
+				if p != pdf417Stop>>1 {
+					t.Fatalf("row %d: bad stop pattern", y)
+				}
+			default:
+				v := -1
+				for i, q := range pdf417Patterns[cluster] {
+					if q == p {
+						v = i
+					}
+				}
+				if v < 0 {
+					t.Fatalf("row %d: bad codeword at %d", y, x)
+				}
+				row = append(row, v)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestPDF417(t *testing.T) {
+	for _, tt := range []struct {
+		s     string
+		level int
+	}{
+		{"HELLO", 2},
+		{"Hello, World! 12:30", 0},
+		{"0123456789012345678901234567890123456789012345678901", 3},
+		{"\x00\x01\x02\xff\xfe\xfd", 4},
+		{strings.Repeat("PDF417 ", 100), 5},
+	} {
+		c, err := PDF417(tt.s, tt.level)
+		if err != nil {
+			t.Errorf("%q: %v", tt.s, err)
+			continue
+		}
+		rows := pdf417Decode(t, c)
+		nrows, cols := len(rows), len(rows[0])-2
+		var cw []int
+		for y, row := range rows {
+			// Row indicators encode number of rows and columns and
+			// error correction level.
+			base := y / 3 * 30
+			ind := [3][2]int{
+				{base + (nrows-1)/3, base + cols - 1},
+				{base + tt.level*3 + (nrows-1)%3, base + (nrows-1)/3},
+				{base + cols - 1, base + tt.level*3 + (nrows-1)%3},
+			}[y%3]
+			if row[0] != ind[0] || row[len(row)-1] != ind[1] {
+				t.Errorf("%q: row %d: bad row indicators", tt.s, y)
+			}
+			cw = append(cw, row[1:len(row)-1]...)
+		}
+		ecLen := 2 << uint(tt.level)
+		if cw[0] != len(cw)-ecLen {
+			t.Errorf("%q: length descriptor is %d, want %d", tt.s, cw[0], len(cw)-ecLen)
+		}
+		data := pdf417Encode(tt.s)
+		if !reflect.DeepEqual(cw[1:1+len(data)], data) {
+			t.Errorf("%q: bad data codewords", tt.s)
+		}
+		// Codewords polynomial is zero at roots of generator polynomial.
+		a := 1
+		for i := 0; i < ecLen; i++ {
+			a = a * 3 % 929
+			s := 0
+			for _, v := range cw {
+				s = (s*a + v) % 929
+			}
+			if s != 0 {
+				t.Errorf("%q: syndrome %d is not zero", tt.s, i)
+				break
+			}
+		}
+	}
+}
+
+func TestPDF417Encode(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+	}{
+		// H E, L L, O pad
+		{"HELLO", []int{7*30 + 4, 11*30 + 11, 14*30 + 29}},
+		// A ll, b ml, 1 ps, ! pad
+		{"Ab1!", []int{0*30 + 27, 1*30 + 28, 1*30 + 29, 10*30 + 29}},
+		{"000123", []int{902, 1, 211, 223}},
+		{"\x00\x00\x00\x00\x00\x01", []int{924, 0, 0, 0, 0, 1}},
+		{"\xff", []int{901, 255}},
+	}
+	for _, tt := range tests {
+		got := pdf417Encode(tt.s)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestBarsAndImage(t *testing.T) {
+	c, err := Code128("HELLO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bars := c.Bars()
+	// Code 128 symbol has 3 bars, and stop symbol has 4.
+	if len(bars) != 3*7+4 {
+		t.Fatalf("got %d bars, want %d", len(bars), 3*7+4)
+	}
+	if want := (Bar{X: 0, Y: 0, Width: 2, Height: 1}); bars[0] != want {
+		t.Errorf("first bar is %v, want %v", bars[0], want)
+	}
+	m := c.Image(3, 50)
+	if m.Width != c.Width*3 || m.Height != 50 {
+		t.Fatalf("image size is %dx%d, want %dx%d", m.Width, m.Height, c.Width*3, 50)
+	}
+	for _, b := range bars {
+		for x := b.X * 3; x < (b.X+b.Width)*3; x++ {
+			if !m.Black(x, 49) {
+				t.Fatalf("pixel %d of bar %v is white", x, b)
+			}
+		}
+		if m.Black(b.X*3-1, 0) || m.Black((b.X+b.Width)*3, 0) {
+			t.Fatalf("bar %v is too wide", b)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+// code128Patterns lists element widths of Code 128 symbols 0 to 106.
+// Every symbol is three bars and three spaces, 11 modules wide. Stop
+// symbol has one more bar.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// Code 128 code sets and special symbols.
+const (
+	code128A = iota
+	code128B
+	code128C
+
+	code128CodeC  = 99
+	code128CodeB  = 100
+	code128CodeA  = 101
+	code128StartA = 103
+	code128Stop   = 106
+)
+
+// digitRun returns number of decimal digits at the start of s.
+func digitRun(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// code128Set returns code set to use for text s, which does not
+// start with digits worth encoding in code set C.
+func code128Set(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] < ' ':
+			return code128A
+		case s[i] >= '`':
+			return code128B
+		}
+	}
+	return code128B
+}
+
+// code128Symbols returns Code 128 symbols encoding s, starting with
+// start symbol and without check symbol. Code set is switched to C for
+// runs of at least 4 digits at the start or end of s and at least 6
+// digits elsewhere.
+func code128Symbols(s string) ([]int, error) {
+	var syms []int
+	set := -1
+	for len(s) > 0 {
+		n := digitRun(s)
+		if n >= 6 || n >= 4 && (n == len(s) || set < 0) || n == 2 && len(s) == 2 && set < 0 {
+			if n%2 == 1 {
+				// Odd digit is encoded in the current code set,
+				// or after code set C, if this is the start.
+				if set >= 0 {
+					c, err := code128Char(set, s[0])
+					if err != nil {
+						return nil, err
+					}
+					syms = append(syms, c)
+					s = s[1:]
+				}
+				n--
+			}
+			if set != code128C {
+				if set < 0 {
+					syms = append(syms, code128StartA+code128C)
+				} else {
+					syms = append(syms, code128CodeC)
+				}
+				set = code128C
+			}
+			for ; n > 0; n -= 2 {
+				syms = append(syms, int(s[0]-'0')*10+int(s[1]-'0'))
+				s = s[2:]
+			}
+			continue
+		}
+		if set == code128C || set < 0 || set == code128A && s[0] >= '`' || set == code128B && s[0] < ' ' {
+			next := code128Set(s)
+			switch {
+			case set < 0:
+				syms = append(syms, code128StartA+next)
+			case next == code128A:
+				syms = append(syms, code128CodeA)
+			default:
+				syms = append(syms, code128CodeB)
+			}
+			set = next
+		}
+		c, err := code128Char(set, s[0])
+		if err != nil {
+			return nil, err
+		}
+		syms = append(syms, c)
+		s = s[1:]
+	}
+	if set < 0 {
+		return nil, ErrData
+	}
+	return syms, nil
+}
+
+// code128Char returns symbol of character c in code set A or B.
+func code128Char(set int, c byte) (int, error) {
+	switch {
+	case c >= 128:
+		return 0, ErrData
+	case set == code128A && c < ' ':
+		return int(c) + 64, nil
+	case set == code128A && c < '`', set == code128B && c >= ' ':
+		return int(c) - ' ', nil
+	}
+	return 0, ErrData
+}
+
+// Code128 returns Code 128 barcode of ASCII text s. Code sets are
+// chosen to make the barcode short.
+func Code128(s string) (*Code, error) {
+	syms, err := code128Symbols(s)
+	if err != nil {
+		return nil, err
+	}
+	sum := syms[0]
+	for i, c := range syms[1:] {
+		sum += (i + 1) * c
+	}
+	syms = append(syms, sum%103, code128Stop)
+	var m []bool
+	for _, c := range syms {
+		var w []int
+		for _, r := range code128Patterns[c] {
+			w = append(w, int(r-'0'))
+		}
+		m = widths(m, w...)
+	}
+	return newLinear(m), nil
+}
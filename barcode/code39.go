@@ -0,0 +1,66 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+import "strings"
+
+// code39Chars lists characters of Code 39 in order of their values.
+const code39Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"
+
+// code39Patterns lists narrow (n) and wide (w) elements of Code 39
+// characters in order of code39Chars, followed by start/stop character.
+var code39Patterns = [...]string{
+	"nnnwwnwnn", "wnnwnnnnw", "nnwwnnnnw", "wnwwnnnnn", "nnnwwnnnw",
+	"wnnwwnnnn", "nnwwwnnnn", "nnnwnnwnw", "wnnwnnwnn", "nnwwnnwnn",
+	"wnnnnwnnw", "nnwnnwnnw", "wnwnnwnnn", "nnnnwwnnw", "wnnnwwnnn",
+	"nnwnwwnnn", "nnnnnwwnw", "wnnnnwwnn", "nnwnnwwnn", "nnnnwwwnn",
+	"wnnnnnnww", "nnwnnnnww", "wnwnnnnwn", "nnnnwnnww", "wnnnwnnwn",
+	"nnwnwnnwn", "nnnnnnwww", "wnnnnnwwn", "nnwnnnwwn", "nnnnwnwwn",
+	"wwnnnnnnw", "nwwnnnnnw", "wwwnnnnnn", "nwnnwnnnw", "wwnnwnnnn",
+	"nwwnwnnnn", "nwnnnnwnw", "wwnnnnwnn", "nwwnnnwnn", "nwnwnwnnn",
+	"nwnwnnnwn", "nwnnnwnwn", "nnnwnwnwn",
+	"nwnnwnwnn",
+}
+
+// code39Wide is width of Code 39 wide element in modules.
+const code39Wide = 3
+
+// Code39 returns Code 39 barcode of text s. s can contain digits,
+// capital letters, space and characters "-.$/+%". If checksum is
+// true, modulo 43 check character is added.
+func Code39(s string, checksum bool) (*Code, error) {
+	var vals []int
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(code39Chars, s[i])
+		if v < 0 {
+			return nil, ErrData
+		}
+		vals = append(vals, v)
+		sum += v
+	}
+	if checksum {
+		vals = append(vals, sum%43)
+	}
+	start := len(code39Patterns) - 1
+	vals = append([]int{start}, append(vals, start)...)
+	var m []bool
+	for i, v := range vals {
+		if i > 0 {
+			// Narrow space between characters.
+			m = append(m, false)
+		}
+		for j, e := range code39Patterns[v] {
+			w := 1
+			if e == 'w' {
+				w = code39Wide
+			}
+			for ; w > 0; w-- {
+				m = append(m, j%2 == 0)
+			}
+		}
+	}
+	return newLinear(m), nil
+}
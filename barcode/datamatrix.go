@@ -0,0 +1,265 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+// dmSize describes square DataMatrix ECC 200 symbol.
+type dmSize struct {
+	size    int // in modules
+	regions int // data regions in each direction
+	ecc     int // error correction codewords
+	blocks  int // interleaved blocks
+}
+
+// dmSizes lists square DataMatrix symbol sizes.
+var dmSizes = []dmSize{
+	{10, 1, 5, 1},
+	{12, 1, 7, 1},
+	{14, 1, 10, 1},
+	{16, 1, 12, 1},
+	{18, 1, 14, 1},
+	{20, 1, 18, 1},
+	{22, 1, 20, 1},
+	{24, 1, 24, 1},
+	{26, 1, 28, 1},
+	{32, 2, 36, 1},
+	{36, 2, 42, 1},
+	{40, 2, 48, 1},
+	{44, 2, 56, 1},
+	{48, 2, 68, 1},
+	{52, 2, 84, 2},
+	{64, 4, 112, 2},
+	{72, 4, 144, 4},
+	{80, 4, 192, 4},
+	{88, 4, 224, 4},
+	{96, 4, 272, 4},
+	{104, 4, 336, 6},
+	{120, 6, 408, 6},
+	{132, 6, 496, 8},
+	{144, 6, 620, 10},
+}
+
+// regionSize returns size of data region without finder pattern.
+func (s *dmSize) regionSize() int {
+	return s.size/s.regions - 2
+}
+
+// matrixSize returns size of all data regions put together.
+func (s *dmSize) matrixSize() int {
+	return s.regionSize() * s.regions
+}
+
+// dataCodewords returns number of data codewords of the symbol.
+func (s *dmSize) dataCodewords() int {
+	n := s.matrixSize()
+	return n*n/8 - s.ecc
+}
+
+// dmEncode returns data codewords of data in ASCII encodation. Pairs
+// of digits are encoded in single codeword.
+func dmEncode(data []byte) []byte {
+	var cw []byte
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case isDigit(c) && i+1 < len(data) && isDigit(data[i+1]):
+			cw = append(cw, 130+(c-'0')*10+data[i+1]-'0')
+			i++
+		case c >= 128:
+			// Upper shift.
+			cw = append(cw, 235, c-127)
+		default:
+			cw = append(cw, c+1)
+		}
+	}
+	return cw
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// DataMatrix returns square DataMatrix ECC 200 code of data.
+func DataMatrix(data []byte) (*Code, error) {
+	cw := dmEncode(data)
+	var s *dmSize
+	for i := range dmSizes {
+		if dmSizes[i].dataCodewords() >= len(cw) {
+			s = &dmSizes[i]
+			break
+		}
+	}
+	if s == nil {
+		return nil, ErrTooLarge
+	}
+	// Pad codewords are 129, followed by pseudo random values.
+	n := s.dataCodewords()
+	for i, first := len(cw), len(cw); i < n; i++ {
+		pad := 129
+		if i > first {
+			pad = 129 + (149*(i+1))%253 + 1
+			if pad > 254 {
+				pad -= 254
+			}
+		}
+		cw = append(cw, byte(pad))
+	}
+	cw = dmECC(cw, s)
+
+	m := dmPlace(cw, s.matrixSize())
+	c := newCode(s.size, s.size)
+	rs := s.regionSize()
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			ry, rx := y%(rs+2), x%(rs+2)
+			var black bool
+			switch {
+			case rx == 0 || ry == rs+1:
+				// Solid left and bottom edges of finder pattern.
+				black = true
+			case ry == 0:
+				black = x%2 == 0
+			case rx == rs+1:
+				black = y%2 == 1
+			default:
+				black = m[(y/(rs+2)*rs+ry-1)*s.matrixSize()+x/(rs+2)*rs+rx-1]
+			}
+			c.set(x, y, black)
+		}
+	}
+	return c, nil
+}
+
+// dmECC returns data codewords cw of symbol s followed by error
+// correction codewords. Codewords of blocks are interleaved.
+func dmECC(cw []byte, s *dmSize) []byte {
+	n := len(cw)
+	ecLen := s.ecc / s.blocks
+	out := make([]byte, n+s.ecc)
+	copy(out, cw)
+	for b := 0; b < s.blocks; b++ {
+		var block []byte
+		for i := b; i < n; i += s.blocks {
+			block = append(block, cw[i])
+		}
+		for i, e := range dmField.ecc(block, ecLen, 1) {
+			out[n+b+i*s.blocks] = e
+		}
+	}
+	return out
+}
+
+// dmPlacement places codewords into mapping matrix, as defined by
+// DataMatrix specification.
+type dmPlacement struct {
+	nrow, ncol int
+	cw         []byte
+	bits       []bool
+	set        []bool
+}
+
+// dmPlace returns modules of codewords cw placed into n by n matrix.
+func dmPlace(cw []byte, n int) []bool {
+	p := &dmPlacement{
+		nrow: n,
+		ncol: n,
+		cw:   cw,
+		bits: make([]bool, n*n),
+		set:  make([]bool, n*n),
+	}
+	nrow, ncol := n, n
+	chr := 0
+	row, col := 4, 0
+	for {
+		switch {
+		case row == nrow && col == 0:
+			p.corner(chr, [8][2]int{{nrow - 1, 0}, {nrow - 1, 1}, {nrow - 1, 2}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 1}, {2, ncol - 1}, {3, ncol - 1}})
+			chr++
+		case row == nrow-2 && col == 0 && ncol%4 != 0:
+			p.corner(chr, [8][2]int{{nrow - 3, 0}, {nrow - 2, 0}, {nrow - 1, 0}, {0, ncol - 4}, {0, ncol - 3}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 1}})
+			chr++
+		case row == nrow-2 && col == 0 && ncol%8 == 4:
+			p.corner(chr, [8][2]int{{nrow - 3, 0}, {nrow - 2, 0}, {nrow - 1, 0}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 1}, {2, ncol - 1}, {3, ncol - 1}})
+			chr++
+		case row == nrow+4 && col == 2 && ncol%8 == 0:
+			p.corner(chr, [8][2]int{{nrow - 1, 0}, {nrow - 1, ncol - 1}, {0, ncol - 3}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 3}, {1, ncol - 2}, {1, ncol - 1}})
+			chr++
+		}
+		// Sweep up and right.
+		for {
+			if row < nrow && col >= 0 && !p.set[row*ncol+col] {
+				p.utah(row, col, chr)
+				chr++
+			}
+			row -= 2
+			col += 2
+			if row < 0 || col >= ncol {
+				break
+			}
+		}
+		row++
+		col += 3
+		// Sweep down and left.
+		for {
+			if row >= 0 && col < ncol && !p.set[row*ncol+col] {
+				p.utah(row, col, chr)
+				chr++
+			}
+			row += 2
+			col -= 2
+			if row >= nrow || col < 0 {
+				break
+			}
+		}
+		row += 3
+		col++
+		if row >= nrow && col >= ncol {
+			break
+		}
+	}
+	// Fixed pattern in unused lower right corner.
+	if !p.set[n*n-1] {
+		p.bits[n*n-1] = true
+		p.bits[(n-2)*n+n-2] = true
+	}
+	return p.bits
+}
+
+// module places bit of codeword chr at row, col. Bit 0 is the most
+// significant bit. Positions outside of the matrix wrap around.
+func (p *dmPlacement) module(row, col, chr, bit int) {
+	if row < 0 {
+		row += p.nrow
+		col += 4 - (p.nrow+4)%8
+	}
+	if col < 0 {
+		col += p.ncol
+		row += 4 - (p.ncol+4)%8
+	}
+	i := row*p.ncol + col
+	p.set[i] = true
+	if chr < len(p.cw) {
+		p.bits[i] = p.cw[chr]>>uint(7-bit)&1 != 0
+	}
+}
+
+// utah places codeword chr in standard shape with bottom right
+// module at row, col.
+func (p *dmPlacement) utah(row, col, chr int) {
+	p.module(row-2, col-2, chr, 0)
+	p.module(row-2, col-1, chr, 1)
+	p.module(row-1, col-2, chr, 2)
+	p.module(row-1, col-1, chr, 3)
+	p.module(row-1, col, chr, 4)
+	p.module(row, col-2, chr, 5)
+	p.module(row, col-1, chr, 6)
+	p.module(row, col, chr, 7)
+}
+
+// corner places codeword chr in special corner shape pos.
+func (p *dmPlacement) corner(chr int, pos [8][2]int) {
+	for bit, rc := range pos {
+		p.module(rc[0], rc[1], chr, bit)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+// eanL lists EAN odd parity (L) patterns of digits. Even parity (G)
+// patterns are L patterns reversed and inverted, and right hand (R)
+// patterns are L patterns inverted.
+var eanL = [10]uint8{
+	0x0d, 0x19, 0x13, 0x3d, 0x23, 0x31, 0x2f, 0x3b, 0x37, 0x0b,
+}
+
+// eanParity lists parity of left hand digits of EAN-13, selected by
+// the first digit. Set bit means even parity, and the first left hand
+// digit is the most significant of 6 bits.
+var eanParity = [10]uint8{
+	0x00, 0x0b, 0x0d, 0x0e, 0x13, 0x19, 0x1c, 0x15, 0x16, 0x1a,
+}
+
+// eanCheck returns check digit of d, calculated as EAN and UPC do.
+func eanCheck(d []int) int {
+	sum := 0
+	for i := range d {
+		w := 1
+		if (len(d)-i)%2 == 1 {
+			w = 3
+		}
+		sum += w * d[i]
+	}
+	return (10 - sum%10) % 10
+}
+
+// eanDigits returns n digits of s, with check digit calculated, if s
+// has n-1 digits, or verified, if s has n digits.
+func eanDigits(s string, n int) ([]int, error) {
+	d, ok := digits(s)
+	if !ok {
+		return nil, ErrData
+	}
+	switch len(d) {
+	case n - 1:
+		return append(d, eanCheck(d)), nil
+	case n:
+		if d[n-1] != eanCheck(d[:n-1]) {
+			return nil, ErrData
+		}
+		return d, nil
+	}
+	return nil, ErrData
+}
+
+// eanDigit appends 7 modules of pattern p to m.
+func eanDigit(m []bool, p uint8) []bool {
+	for i := 6; i >= 0; i-- {
+		m = append(m, p>>uint(i)&1 != 0)
+	}
+	return m
+}
+
+// reverse7 returns 7 bits of p in reverse order.
+func reverse7(p uint8) uint8 {
+	var r uint8
+	for i := 0; i < 7; i++ {
+		r = r<<1 | p>>uint(i)&1
+	}
+	return r
+}
+
+// EAN13 returns EAN-13 barcode of s. s is 12 digits, and check digit
+// is added, or 13 digits with valid check digit.
+func EAN13(s string) (*Code, error) {
+	d, err := eanDigits(s, 13)
+	if err != nil {
+		return nil, err
+	}
+	m := widths(nil, 1, 1, 1)
+	parity := eanParity[d[0]]
+	for i, v := range d[1:7] {
+		p := eanL[v]
+		if parity>>uint(5-i)&1 != 0 {
+			p = reverse7(^p & 0x7f)
+		}
+		m = eanDigit(m, p)
+	}
+	m = append(m, false, true, false, true, false)
+	for _, v := range d[7:] {
+		m = eanDigit(m, ^eanL[v]&0x7f)
+	}
+	m = widths(m, 1, 1, 1)
+	return newLinear(m), nil
+}
+
+// UPCA returns UPC-A barcode of s. s is 11 digits, and check digit
+// is added, or 12 digits with valid check digit.
+func UPCA(s string) (*Code, error) {
+	d, err := eanDigits(s, 12)
+	if err != nil {
+		return nil, err
+	}
+	// UPC-A is EAN-13 with leading zero.
+	b := []byte{'0'}
+	for _, v := range d {
+		b = append(b, byte('0'+v))
+	}
+	return EAN13(string(b))
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+// itfPatterns lists narrow (n) and wide (w) elements of digits of
+// Interleaved 2 of 5.
+var itfPatterns = [10]string{
+	"nnwwn", "wnnnw", "nwnnw", "wwnnn", "nnwnw",
+	"wnwnn", "nwwnn", "nnnww", "wnnwn", "nwnwn",
+}
+
+// itfWide is width of ITF wide element in modules.
+const itfWide = 3
+
+// ITF returns Interleaved 2 of 5 barcode of digits s. Digits are
+// encoded in pairs, so s must have even number of digits. If checksum
+// is true, check digit is added to s, and s must have odd number of
+// digits.
+func ITF(s string, checksum bool) (*Code, error) {
+	d, ok := digits(s)
+	if !ok || len(d) == 0 {
+		return nil, ErrData
+	}
+	if checksum {
+		d = append(d, eanCheck(d))
+	}
+	if len(d)%2 != 0 {
+		return nil, ErrData
+	}
+	m := widths(nil, 1, 1, 1, 1)
+	for i := 0; i < len(d); i += 2 {
+		bars, spaces := itfPatterns[d[i]], itfPatterns[d[i+1]]
+		for j := 0; j < 5; j++ {
+			m = widths(m, itfWidth(bars[j]), itfWidth(spaces[j]))
+		}
+	}
+	m = widths(m, itfWide, 1, 1)
+	return newLinear(m), nil
+}
+
+func itfWidth(e byte) int {
+	if e == 'w' {
+		return itfWide
+	}
+	return 1
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+import (
+	"math/big"
+	"strings"
+)
+
+// PDF417 start and stop patterns. Stop pattern is 18 modules wide.
+const (
+	pdf417Start = 0x1fea8
+	pdf417Stop  = 0x3fa29
+)
+
+// PDF417 mode latch codewords.
+const (
+	pdf417Byte6   = 924 // byte compaction of multiple of 6 bytes
+	pdf417Byte    = 901
+	pdf417Numeric = 902
+	pdf417Pad     = 900
+)
+
+// PDF417 text compaction submodes and their switch values.
+const (
+	pdf417Alpha = iota
+	pdf417Lower
+	pdf417Mixed
+
+	pdf417LatchLower = 27
+	pdf417LatchMixed = 28
+	pdf417LatchAlpha = 28 // from mixed submode
+	pdf417ShiftAlpha = 27 // from lower submode
+	pdf417ShiftPunct = 29
+)
+
+// Characters of PDF417 mixed and punctuation submodes in order of
+// their values.
+const (
+	pdf417MixedChars = "0123456789&\r\t,:#-.$/+%*=^"
+	pdf417PunctChars = ";<>@[\\]_`~!\r\t,:\n-.$/\"|*()?{}'"
+)
+
+// PDF417 returns PDF417 code of s with error correction level from
+// 0 to 8. Level 0 only detects errors, and every next level doubles
+// number of error correction codewords. Recommended level is 2 for
+// up to 40 data codewords, 3 for up to 160, 4 for up to 320 and 5 for
+// larger codes.
+//
+// Every row of the code is one module high. PDF417 rows should be
+// printed at least 3 times higher than module width.
+func PDF417(s string, level int) (*Code, error) {
+	if level < 0 || level > 8 {
+		return nil, ErrData
+	}
+	data := pdf417Encode(s)
+	ecLen := 2 << uint(level)
+	cols, rows := pdf417Dimensions(1 + len(data) + ecLen)
+	if cols == 0 {
+		return nil, ErrTooLarge
+	}
+	// Symbol length descriptor, data and padding.
+	n := cols*rows - ecLen
+	cw := make([]int, 0, cols*rows)
+	cw = append(cw, n)
+	cw = append(cw, data...)
+	for len(cw) < n {
+		cw = append(cw, pdf417Pad)
+	}
+	cw = append(cw, pdf417ECC(cw, ecLen)...)
+
+	c := newCode(17*(cols+4)+1, rows)
+	for y := 0; y < rows; y++ {
+		cluster := y % 3
+		base := y / 3 * 30
+		var left, right int
+		switch cluster {
+		case 0:
+			left = base + (rows-1)/3
+			right = base + cols - 1
+		case 1:
+			left = base + level*3 + (rows-1)%3
+			right = base + (rows-1)/3
+		case 2:
+			left = base + cols - 1
+			right = base + level*3 + (rows-1)%3
+		}
+		x := 0
+		put := func(p uint32, n int) {
+			for i := n - 1; i >= 0; i-- {
+				c.set(x, y, p>>uint(i)&1 != 0)
+				x++
+			}
+		}
+		put(pdf417Start, 17)
+		put(pdf417Patterns[cluster][left], 17)
+		for _, v := range cw[y*cols : (y+1)*cols] {
+			put(pdf417Patterns[cluster][v], 17)
+		}
+		put(pdf417Patterns[cluster][right], 17)
+		put(pdf417Stop, 18)
+	}
+	return c, nil
+}
+
+// pdf417Dimensions returns number of columns and rows of code with
+// n codewords. Codes printed with rows 3 times higher than module
+// width are made about twice as wide as high.
+func pdf417Dimensions(n int) (cols, rows int) {
+	best := 0
+	for c := 1; c <= 30; c++ {
+		r := (n + c - 1) / c
+		if r < 3 {
+			r = 3
+		}
+		if r > 90 || c*r > 928 {
+			continue
+		}
+		// Difference of width and twice the height.
+		d := abs(17*(c+4) + 1 - 2*3*r)
+		if cols == 0 || d < best {
+			best, cols, rows = d, c, r
+		}
+	}
+	return cols, rows
+}
+
+// pdf417Encode returns data codewords of s. Numeric compaction is used
+// for s of digits, text compaction for printable ASCII text, and byte
+// compaction otherwise.
+func pdf417Encode(s string) []int {
+	if len(s) > 0 {
+		if _, ok := digits(s); ok {
+			return pdf417EncodeNumeric(s)
+		}
+	}
+	if cw, ok := pdf417EncodeText(s); ok {
+		return cw
+	}
+	return pdf417EncodeBytes(s)
+}
+
+// pdf417EncodeNumeric returns codewords of digits s in numeric
+// compaction. Groups of 44 digits prefixed by 1 are converted to base 900.
+func pdf417EncodeNumeric(s string) []int {
+	cw := []int{pdf417Numeric}
+	for len(s) > 0 {
+		n := len(s)
+		if n > 44 {
+			n = 44
+		}
+		v, _ := new(big.Int).SetString("1"+s[:n], 10)
+		var group []int
+		m := new(big.Int)
+		b900 := big.NewInt(900)
+		for v.Sign() > 0 {
+			v.DivMod(v, b900, m)
+			group = append([]int{int(m.Int64())}, group...)
+		}
+		cw = append(cw, group...)
+		s = s[n:]
+	}
+	return cw
+}
+
+// pdf417EncodeText returns codewords of s in text compaction, which is
+// the default mode, or false if s is not printable ASCII text.
+func pdf417EncodeText(s string) ([]int, bool) {
+	var v []int
+	mode := pdf417Alpha
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z' || c == ' ' && mode == pdf417Alpha:
+			switch mode {
+			case pdf417Lower:
+				if c != ' ' {
+					v = append(v, pdf417ShiftAlpha, int(c-'A'))
+					continue
+				}
+			case pdf417Mixed:
+				v = append(v, pdf417LatchAlpha)
+				mode = pdf417Alpha
+			}
+			v = append(v, alphaValue(c))
+		case c >= 'a' && c <= 'z' || c == ' ' && mode == pdf417Lower:
+			if mode != pdf417Lower {
+				v = append(v, pdf417LatchLower)
+				mode = pdf417Lower
+			}
+			v = append(v, alphaValue(c))
+		case strings.IndexByte(pdf417MixedChars, c) >= 0 || c == ' ':
+			if mode != pdf417Mixed {
+				if p := strings.IndexByte(pdf417PunctChars, c); p >= 0 {
+					v = append(v, pdf417ShiftPunct, p)
+					continue
+				}
+				v = append(v, pdf417LatchMixed)
+				mode = pdf417Mixed
+			}
+			if c == ' ' {
+				v = append(v, 26)
+			} else {
+				v = append(v, strings.IndexByte(pdf417MixedChars, c))
+			}
+		case strings.IndexByte(pdf417PunctChars, c) >= 0:
+			v = append(v, pdf417ShiftPunct, strings.IndexByte(pdf417PunctChars, c))
+		default:
+			return nil, false
+		}
+	}
+	if len(v)%2 == 1 {
+		v = append(v, pdf417ShiftPunct)
+	}
+	cw := make([]int, len(v)/2)
+	for i := range cw {
+		cw[i] = v[2*i]*30 + v[2*i+1]
+	}
+	return cw, true
+}
+
+// alphaValue returns value of capital letter or space c in alpha
+// submode. Lower submode uses the same values for small letters.
+func alphaValue(c byte) int {
+	if c == ' ' {
+		return 26
+	}
+	return int(c&^0x20 - 'A')
+}
+
+// pdf417EncodeBytes returns codewords of s in byte compaction. Groups
+// of 6 bytes are converted to 5 base 900 codewords, and remaining
+// bytes are encoded one per codeword.
+func pdf417EncodeBytes(s string) []int {
+	cw := []int{pdf417Byte}
+	if len(s)%6 == 0 {
+		cw[0] = pdf417Byte6
+	}
+	for ; len(s) >= 6; s = s[6:] {
+		var v uint64
+		for i := 0; i < 6; i++ {
+			v = v<<8 | uint64(s[i])
+		}
+		var group [5]int
+		for i := 4; i >= 0; i-- {
+			group[i] = int(v % 900)
+			v /= 900
+		}
+		cw = append(cw, group[:]...)
+	}
+	for i := 0; i < len(s); i++ {
+		cw = append(cw, int(s[i]))
+	}
+	return cw
+}
+
+// pdf417ECC returns n Reed-Solomon error correction codewords of
+// data, calculated in prime field of 929 elements.
+func pdf417ECC(data []int, n int) []int {
+	// Generator polynomial (x - 3)(x - 3^2)...(x - 3^n), lowest
+	// degree first.
+	g := []int{1}
+	a := 1
+	for i := 0; i < n; i++ {
+		a = a * 3 % 929
+		next := make([]int, len(g)+1)
+		for j, c := range g {
+			next[j+1] = (next[j+1] + c) % 929
+			next[j] = (next[j] + (929-a)*c) % 929
+		}
+		g = next
+	}
+	e := make([]int, n)
+	for _, d := range data {
+		t := (d + e[n-1]) % 929
+		for j := n - 1; j > 0; j-- {
+			e[j] = (e[j-1] + 929 - t*g[j]%929) % 929
+		}
+		e[0] = (929 - t*g[0]%929) % 929
+	}
+	out := make([]int, n)
+	for j := range e {
+		out[n-1-j] = (929 - e[j]) % 929
+	}
+	return out
+}
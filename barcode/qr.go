@@ -0,0 +1,525 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+import "strings"
+
+// QRLevel is QR code error correction level.
+type QRLevel byte
+
+const (
+	QRLevelL QRLevel = 'L' // recovers 7% of data
+	QRLevelM QRLevel = 'M' // recovers 15% of data
+	QRLevelQ QRLevel = 'Q' // recovers 25% of data
+	QRLevelH QRLevel = 'H' // recovers 30% of data
+)
+
+// index returns row of level in qrECC and qrBlocks tables.
+func (l QRLevel) index() int {
+	switch l {
+	case QRLevelL:
+		return 0
+	case QRLevelM:
+		return 1
+	case QRLevelQ:
+		return 2
+	case QRLevelH:
+		return 3
+	}
+	return -1
+}
+
+// qrECC lists number of error correction codewords per block of
+// versions 1 to 40 for levels L, M, Q and H.
+var qrECC = [4][40]int{
+	{7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// qrBlocks lists number of error correction blocks of versions 1
+// to 40 for levels L, M, Q and H.
+var qrBlocks = [4][40]int{
+	{1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// qrAlphanumeric lists characters of QR alphanumeric mode in order
+// of their values.
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// QR data modes.
+const (
+	qrNumeric      = 1
+	qrAlnum        = 2
+	qrByte         = 4
+	qrVersionLimit = 40
+)
+
+// qrMode returns the most compact mode that can encode whole s.
+func qrMode(s string) int {
+	mode := qrNumeric
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+		case strings.IndexByte(qrAlphanumeric, c) >= 0:
+			mode = qrAlnum
+		default:
+			return qrByte
+		}
+	}
+	return mode
+}
+
+// qrCountBits returns length of character count of mode in version v.
+func qrCountBits(mode, v int) int {
+	i := 0
+	switch {
+	case v >= 27:
+		i = 2
+	case v >= 10:
+		i = 1
+	}
+	switch mode {
+	case qrNumeric:
+		return [3]int{10, 12, 14}[i]
+	case qrAlnum:
+		return [3]int{9, 11, 13}[i]
+	}
+	return [3]int{8, 16, 16}[i]
+}
+
+// qrDataBits returns number of bits of s encoded in mode, without
+// mode indicator and character count.
+func qrDataBits(mode int, s string) int {
+	n := len(s)
+	switch mode {
+	case qrNumeric:
+		return n/3*10 + [3]int{0, 4, 7}[n%3]
+	case qrAlnum:
+		return n/2*11 + n%2*6
+	}
+	return n * 8
+}
+
+// bitBuffer is sequence of bits, most significant bit first.
+type bitBuffer struct {
+	b []byte
+	n int // number of bits
+}
+
+// write appends n low bits of v.
+func (b *bitBuffer) write(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.n%8 == 0 {
+			b.b = append(b.b, 0)
+		}
+		if v>>uint(i)&1 != 0 {
+			b.b[b.n/8] |= 0x80 >> uint(b.n%8)
+		}
+		b.n++
+	}
+}
+
+// qrSize returns size of QR code version v in modules.
+func qrSize(v int) int {
+	return 4*v + 17
+}
+
+// qrCodewords returns number of codewords of QR code version v.
+func qrCodewords(v int) int {
+	n := (16*v+128)*v + 64
+	if v >= 2 {
+		a := v/7 + 2
+		n -= (25*a-10)*a - 55
+		if v >= 7 {
+			n -= 36
+		}
+	}
+	return n / 8
+}
+
+// qrAlignment returns center coordinates of alignment patterns of
+// version v.
+func qrAlignment(v int) []int {
+	if v == 1 {
+		return nil
+	}
+	n := v/7 + 2
+	step := (v*8 + n*3 + 5) / (n*4 - 4) * 2
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, qrSize(v)-7; i > 0; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// qrSymbol is QR code being built.
+type qrSymbol struct {
+	*Code
+	function []bool // modules of function patterns
+}
+
+func (q *qrSymbol) setFunction(x, y int, black bool) {
+	q.set(x, y, black)
+	q.function[y*q.Width+x] = true
+}
+
+// QR returns QR code model 2 of text s with error correction level.
+// Numeric or alphanumeric mode is used, if s can be encoded in it,
+// and byte mode otherwise.
+func QR(s string, level QRLevel) (*Code, error) {
+	li := level.index()
+	if li < 0 {
+		return nil, ErrData
+	}
+	mode := qrMode(s)
+	v := 1
+	for ; ; v++ {
+		if v > qrVersionLimit {
+			return nil, ErrTooLarge
+		}
+		data := qrCodewords(v) - qrECC[li][v-1]*qrBlocks[li][v-1]
+		count := qrCountBits(mode, v)
+		if len(s) < 1<<uint(count) && 4+count+qrDataBits(mode, s) <= data*8 {
+			break
+		}
+	}
+	q := &qrSymbol{Code: newCode(qrSize(v), qrSize(v))}
+	q.function = make([]bool, len(q.modules))
+	q.drawFunction(v)
+	q.drawCodewords(qrCodewordsOf(s, mode, v, li))
+
+	// Choose mask that produces the least penalty.
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormat(level, mask)
+		p := q.penalty()
+		if bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormat(level, best)
+	return q.Code, nil
+}
+
+// qrCodewordsOf returns data and error correction codewords of
+// QR code version v with s encoded in mode, interleaved in order of
+// placement into the symbol.
+func qrCodewordsOf(s string, mode, v, li int) []byte {
+	total := qrCodewords(v)
+	ecLen := qrECC[li][v-1]
+	nblocks := qrBlocks[li][v-1]
+	capacity := total - ecLen*nblocks
+
+	var b bitBuffer
+	b.write(mode, 4)
+	b.write(len(s), qrCountBits(mode, v))
+	switch mode {
+	case qrNumeric:
+		for i := 0; i < len(s); i += 3 {
+			n, val := 0, 0
+			for ; n < 3 && i+n < len(s); n++ {
+				val = val*10 + int(s[i+n]-'0')
+			}
+			b.write(val, n*3+1)
+		}
+	case qrAlnum:
+		for i := 0; i < len(s); i += 2 {
+			val := strings.IndexByte(qrAlphanumeric, s[i])
+			if i+1 < len(s) {
+				val = val*45 + strings.IndexByte(qrAlphanumeric, s[i+1])
+				b.write(val, 11)
+			} else {
+				b.write(val, 6)
+			}
+		}
+	default:
+		for i := 0; i < len(s); i++ {
+			b.write(int(s[i]), 8)
+		}
+	}
+	// Terminator, padding to byte boundary and pad codewords.
+	term := capacity*8 - b.n
+	if term > 4 {
+		term = 4
+	}
+	b.write(0, term)
+	b.write(0, (8-b.n%8)%8)
+	for pad := 0xec; len(b.b) < capacity; pad ^= 0xec ^ 0x11 {
+		b.write(pad, 8)
+	}
+
+	// Split data into blocks. Short blocks come first, and long
+	// blocks have one more data codeword.
+	short := nblocks - total%nblocks
+	shortLen := total/nblocks - ecLen
+	var blocks, eccs [][]byte
+	for i, k := 0, 0; i < nblocks; i++ {
+		n := shortLen
+		if i >= short {
+			n++
+		}
+		blocks = append(blocks, b.b[k:k+n])
+		eccs = append(eccs, qrField.ecc(b.b[k:k+n], ecLen, 0))
+		k += n
+	}
+	var out []byte
+	for i := 0; i <= shortLen; i++ {
+		for _, blk := range blocks {
+			if i < len(blk) {
+				out = append(out, blk[i])
+			}
+		}
+	}
+	for i := 0; i < ecLen; i++ {
+		for _, e := range eccs {
+			out = append(out, e[i])
+		}
+	}
+	return out
+}
+
+// drawFunction draws function patterns of version v: finder,
+// timing and alignment patterns, and version information. Format
+// information area is reserved.
+func (q *qrSymbol) drawFunction(v int) {
+	size := q.Width
+	for i := 0; i < size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(size-4, 3)
+	q.drawFinder(3, size-4)
+	pos := qrAlignment(v)
+	for i, x := range pos {
+		for j, y := range pos {
+			last := len(pos) - 1
+			// Skip positions of finder patterns.
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+	// Reserve format information modules.
+	q.drawFormat(QRLevelM, 0)
+	if v >= 7 {
+		rem := v
+		for i := 0; i < 12; i++ {
+			rem = rem<<1 ^ rem>>11*0x1f25
+		}
+		bits := v<<12 | rem
+		for i := 0; i < 18; i++ {
+			black := bits>>uint(i)&1 != 0
+			a, b := size-11+i%3, i/3
+			q.setFunction(a, b, black)
+			q.setFunction(b, a, black)
+		}
+	}
+}
+
+// drawFinder draws finder pattern with separator centered at x, y.
+func (q *qrSymbol) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= q.Width || yy >= q.Height {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			q.setFunction(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawFormat draws format information of level and mask.
+func (q *qrSymbol) drawFormat(level QRLevel, mask int) {
+	data := [4]int{1, 0, 3, 2}[level.index()]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ rem>>9*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool {
+		return bits>>uint(i)&1 != 0
+	}
+	size := q.Width
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.setFunction(size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, size-15+i, bit(i))
+	}
+	// Dark module.
+	q.setFunction(8, size-8, true)
+}
+
+// drawCodewords places data bits into modules that are not part of
+// function patterns, in two module wide columns going up and down
+// from the bottom right corner.
+func (q *qrSymbol) drawCodewords(data []byte) {
+	size := q.Width
+	i := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// Skip vertical timing pattern.
+			right = 5
+		}
+		for vert := 0; vert < size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = size - 1 - vert
+				}
+				if q.function[y*size+x] {
+					continue
+				}
+				// Remainder bits are left white.
+				if i < len(data)*8 {
+					q.set(x, y, data[i/8]>>uint(7-i%8)&1 != 0)
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts data modules selected by mask. Applying the same
+// mask twice removes it.
+func (q *qrSymbol) applyMask(mask int) {
+	for y := 0; y < q.Height; y++ {
+		for x := 0; x < q.Width; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			i := y*q.Width + x
+			if invert && !q.function[i] {
+				q.modules[i] = !q.modules[i]
+			}
+		}
+	}
+}
+
+// qrFinderLike are module sequences that look like finder pattern.
+var qrFinderLike = [2][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// penalty returns penalty score of the symbol, as defined by QR code
+// specification for mask selection.
+func (q *qrSymbol) penalty() int {
+	size := q.Width
+	p := 0
+	// at returns module of row (or column, if vertical) i at j.
+	at := func(vertical bool, i, j int) bool {
+		if vertical {
+			return q.Black(i, j)
+		}
+		return q.Black(j, i)
+	}
+	for _, vertical := range []bool{false, true} {
+		for i := 0; i < size; i++ {
+			// Runs of five or more modules of the same color.
+			run := 1
+			for j := 1; j <= size; j++ {
+				if j < size && at(vertical, i, j) == at(vertical, i, j-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					p += run - 2
+				}
+				run = 1
+			}
+			// Finder like patterns.
+			for j := 0; j+11 <= size; j++ {
+				for _, pat := range qrFinderLike {
+					match := true
+					for k, black := range pat {
+						if at(vertical, i, j+k) != black {
+							match = false
+							break
+						}
+					}
+					if match {
+						p += 40
+					}
+				}
+			}
+		}
+	}
+	// Blocks of 2x2 modules of the same color.
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			c := q.Black(x, y)
+			if c {
+				dark++
+			}
+			if x+1 < size && y+1 < size && c == q.Black(x+1, y) && c == q.Black(x, y+1) && c == q.Black(x+1, y+1) {
+				p += 3
+			}
+		}
+	}
+	// Proportion of dark modules different from 50%.
+	total := size * size
+	p += abs(dark*100/total-50) / 5 * 10
+	return p
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
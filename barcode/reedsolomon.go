@@ -0,0 +1,71 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package barcode
+
+// gf256 is Galois field GF(256) with given primitive polynomial,
+// as used by Reed-Solomon error correction of QR and DataMatrix codes.
+type gf256 struct {
+	exp [510]byte
+	log [256]int
+}
+
+func newGF256(poly int) *gf256 {
+	f := new(gf256)
+	x := 1
+	for i := 0; i < 255; i++ {
+		f.exp[i] = byte(x)
+		f.exp[i+255] = byte(x)
+		f.log[x] = i
+		x <<= 1
+		if x >= 256 {
+			x ^= poly
+		}
+	}
+	return f
+}
+
+var (
+	qrField = newGF256(0x11d)
+	dmField = newGF256(0x12d)
+)
+
+func (f *gf256) mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[f.log[a]+f.log[b]]
+}
+
+// generator returns coefficients of Reed-Solomon generator polynomial
+// (x - a^first)(x - a^(first+1))...(x - a^(first+n-1)), highest degree
+// first, without leading 1.
+func (f *gf256) generator(n, first int) []byte {
+	g := []byte{1}
+	for i := 0; i < n; i++ {
+		r := f.exp[(first+i)%255]
+		next := make([]byte, len(g)+1)
+		for j, c := range g {
+			next[j] ^= c
+			next[j+1] ^= f.mul(c, r)
+		}
+		g = next
+	}
+	return g[1:]
+}
+
+// ecc returns n error correction bytes of data.
+func (f *gf256) ecc(data []byte, n, first int) []byte {
+	g := f.generator(n, first)
+	r := make([]byte, n)
+	for _, c := range data {
+		k := c ^ r[0]
+		copy(r, r[1:])
+		r[n-1] = 0
+		for i := range r {
+			r[i] ^= f.mul(g[i], k)
+		}
+	}
+	return r
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bitmap implements monochrome bitmaps, as used by printers that
+// can only print black dots, and conversion of images into them.
+package bitmap
+
+import (
+	"image"
+	"image/color"
+)
+
+// Bitmap is a monochrome image with one bit per pixel. Set bits are
+// black. Each row starts at byte boundary, and leftmost pixel is stored
+// in the most significant bit of the first byte of the row.
+type Bitmap struct {
+	Width, Height int
+	Stride        int // bytes per row
+	Pix           []byte
+}
+
+// New returns new white bitmap of the given size.
+func New(width, height int) *Bitmap {
+	stride := (width + 7) / 8
+	return &Bitmap{
+		Width:  width,
+		Height: height,
+		Stride: stride,
+		Pix:    make([]byte, stride*height),
+	}
+}
+
+// Row returns pixels of row y.
+func (b *Bitmap) Row(y int) []byte {
+	return b.Pix[y*b.Stride : (y+1)*b.Stride]
+}
+
+// Black reports whether pixel x, y is black.
+func (b *Bitmap) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return false
+	}
+	return b.Pix[y*b.Stride+x/8]&(0x80>>uint(x%8)) != 0
+}
+
+// Set makes pixel x, y black or white.
+func (b *Bitmap) Set(x, y int, black bool) {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return
+	}
+	i, mask := y*b.Stride+x/8, byte(0x80>>uint(x%8))
+	if black {
+		b.Pix[i] |= mask
+	} else {
+		b.Pix[i] &^= mask
+	}
+}
+
+// ColorModel implements image.Image interface.
+func (b *Bitmap) ColorModel() color.Model {
+	return color.GrayModel
+}
+
+// Bounds implements image.Image interface.
+func (b *Bitmap) Bounds() image.Rectangle {
+	return image.Rect(0, 0, b.Width, b.Height)
+}
+
+// At implements image.Image interface.
+func (b *Bitmap) At(x, y int) color.Color {
+	if b.Black(x, y) {
+		return color.Gray{Y: 0}
+	}
+	return color.Gray{Y: 0xff}
+}
+
+// Dither describes how image gray levels are converted to black
+// and white pixels.
+type Dither int
+
+const (
+	// FloydSteinberg diffuses error to neighbour pixels. It suits
+	// photographs and scanned documents.
+	FloydSteinberg Dither = iota
+	// Ordered uses 8x8 Bayer matrix. It produces regular pattern
+	// that suits printers with poor dot placement, like thermal printers.
+	Ordered
+	// Threshold makes pixels darker than 50% gray black. It suits
+	// logos, line art and barcodes.
+	Threshold
+)
+
+// luminance returns gray levels of m pixels. Transparent pixels
+// are composed over white background.
+func luminance(m image.Image) []uint8 {
+	r := m.Bounds()
+	lum := make([]uint8, 0, r.Dx()*r.Dy())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			cr, cg, cb, ca := m.At(x, y).RGBA()
+			// Colors are alpha-premultiplied, so adding
+			// missing alpha composes them over white.
+			w := 0xffff - ca
+			g := (19595*(cr+w) + 38470*(cg+w) + 7471*(cb+w) + 1<<15) >> 24
+			lum = append(lum, uint8(g))
+		}
+	}
+	return lum
+}
+
+// bayer is 8x8 ordered dither matrix.
+var bayer = [8][8]uint8{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// FromImage converts image m into bitmap using dither method d.
+// Bitmap pixel 0, 0 corresponds to m.Bounds().Min.
+func FromImage(m image.Image, d Dither) *Bitmap {
+	r := m.Bounds()
+	w, h := r.Dx(), r.Dy()
+	b := New(w, h)
+	lum := luminance(m)
+	switch d {
+	case Threshold:
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				b.Set(x, y, lum[y*w+x] < 128)
+			}
+		}
+	case Ordered:
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				// Thresholds are spread evenly between 2 and 254.
+				t := int(bayer[y%8][x%8])*4 + 2
+				b.Set(x, y, int(lum[y*w+x]) < t)
+			}
+		}
+	default:
+		// Errors of current and next rows, with one extra
+		// element on each side to avoid bounds checks.
+		cur := make([]int, w+2)
+		next := make([]int, w+2)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				v := int(lum[y*w+x]) + cur[x+1]/16
+				out := 255
+				if v < 128 {
+					out = 0
+					b.Set(x, y, true)
+				}
+				e := v - out
+				cur[x+2] += e * 7
+				next[x] += e * 3
+				next[x+1] += e * 5
+				next[x+2] += e
+			}
+			cur, next = next, cur
+			for i := range next {
+				next[i] = 0
+			}
+		}
+	}
+	return b
+}
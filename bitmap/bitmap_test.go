@@ -0,0 +1,81 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bitmap
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	b := New(10, 2)
+	if b.Stride != 2 || len(b.Pix) != 4 {
+		t.Fatalf("unexpected bitmap stride %d and size %d", b.Stride, len(b.Pix))
+	}
+	b.Set(0, 0, true)
+	b.Set(9, 1, true)
+	b.Set(10, 1, true) // outside, ignored
+	want := []byte{0x80, 0, 0, 0x40}
+	for i := range want {
+		if b.Pix[i] != want[i] {
+			t.Fatalf("bitmap pixels are %x, want %x", b.Pix, want)
+		}
+	}
+	if !b.Black(9, 1) || b.Black(8, 1) || b.Black(-1, 0) {
+		t.Error("Black returned wrong values")
+	}
+	b.Set(0, 0, false)
+	if b.Pix[0] != 0 {
+		t.Error("Set did not clear the pixel")
+	}
+	if c := b.At(9, 1); c != (color.Gray{Y: 0}) {
+		t.Errorf("At returned %v for black pixel", c)
+	}
+}
+
+// blackRatio returns part of black pixels in b.
+func blackRatio(b *Bitmap) float64 {
+	n := 0
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if b.Black(x, y) {
+				n++
+			}
+		}
+	}
+	return float64(n) / float64(b.Width*b.Height)
+}
+
+func TestFromImage(t *testing.T) {
+	m := image.NewNRGBA(image.Rect(5, 5, 8, 6))
+	m.Set(5, 5, color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+	m.Set(6, 5, color.NRGBA{R: 200, G: 200, B: 200, A: 255})
+	m.Set(7, 5, color.NRGBA{A: 0}) // transparent is white
+	b := FromImage(m, Threshold)
+	if b.Width != 3 || b.Height != 1 {
+		t.Fatalf("bitmap size is %dx%d, want 3x1", b.Width, b.Height)
+	}
+	if !b.Black(0, 0) || b.Black(1, 0) || b.Black(2, 0) {
+		t.Errorf("unexpected threshold bitmap %08b", b.Pix[0])
+	}
+
+	for _, d := range []Dither{FloydSteinberg, Ordered} {
+		black := FromImage(image.NewGray(image.Rect(0, 0, 64, 64)), d)
+		if r := blackRatio(black); r != 1 {
+			t.Fatalf("dither %d: black image has %v black pixels", d, r)
+		}
+		for _, level := range []uint8{0, 64, 128, 192, 255} {
+			m := image.NewGray(image.Rect(0, 0, 64, 64))
+			for i := range m.Pix {
+				m.Pix[i] = level
+			}
+			want := 1 - float64(level)/255
+			if r := blackRatio(FromImage(m, d)); r < want-0.03 || r > want+0.03 {
+				t.Errorf("dither %d: gray level %d has %.3f black pixels, want %.3f", d, level, r, want)
+			}
+		}
+	}
+}
@@ -77,22 +77,28 @@ func printOneDocument(printerName, documentName string, lines []string) error {
 	}
 	defer p.Close()
 
-	err = p.StartRawDocument(documentName)
+	d, err := p.NewDocument(documentName, nil)
 	if err != nil {
 		return err
 	}
-	defer p.EndDocument()
 
-	err = p.StartPage()
+	page, err := d.NewPage()
 	if err != nil {
+		d.Abort()
 		return err
 	}
 
 	for _, line := range lines {
-		fmt.Fprintf(p, "%s\r\n", line)
+		fmt.Fprintf(page, "%s\r\n", line)
 	}
 
-	return p.EndPage()
+	err = page.Close()
+	if err != nil {
+		d.Abort()
+		return err
+	}
+	// Close reports any error writing lines above.
+	return d.Close()
 }
 
 func printDocument(path string) error {
@@ -0,0 +1,22 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package printer
+
+import "github.com/alexbrainman/printer/ipp"
+
+// ReadNames returns names of printers of CUPS server
+// (see ipp.NewCUPSClient).
+func ReadNames() ([]string, error) {
+	return cupsClient().PrinterNames()
+}
+
+// ReadNamesOn returns names of printers on CUPS server,
+// like "server" or "server:631".
+func ReadNamesOn(server string) ([]string, error) {
+	return ipp.NewClient(server).PrinterNames()
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package printer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/ipp"
+)
+
+func TestReadNames(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := ipp.Decode(r.Body)
+		if err != nil || req.Code != ipp.OpCUPSGetPrinters {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		resp := ipp.NewRequest(0)
+		resp.Code = ipp.StatusOK
+		resp.RequestID = req.RequestID
+		for _, name := range []string{"laser", "label"} {
+			resp.AddGroup(ipp.TagPrinter).Add("printer-name", ipp.String(ipp.TagName, name))
+		}
+		w.Write(resp.Encode())
+	}))
+	defer s.Close()
+	oldClient := cupsClient
+	cupsClient = func() *ipp.Client { return &ipp.Client{URL: s.URL} }
+	defer func() { cupsClient = oldClient }()
+
+	want := []string{"laser", "label"}
+	names, err := ReadNames()
+	if err != nil {
+		t.Fatalf("ReadNames failed: %v", err)
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("ReadNames returned %q, want %q", names, want)
+	}
+	names, err = ReadNamesOn(strings.TrimPrefix(s.URL, "http://"))
+	if err != nil {
+		t.Fatalf("ReadNamesOn failed: %v", err)
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("ReadNamesOn returned %q, want %q", names, want)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package printer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// device is printer connection.
+type device interface {
+	io.ReadWriteCloser
+	SetReadDeadline(t time.Time) error
+}
+
+// Printer is direct connection to printer device, opened with
+// OpenDevice. It bypasses CUPS, so programs can talk to printers
+// that send replies, like PJL, ESC/POS or ZPL status.
+type Printer struct {
+	dev device
+}
+
+// OpenDevice opens printer device uri. Supported are AppSocket
+// (JetDirect) URIs, like "socket://192.168.1.10:9100", and device
+// files, like "/dev/usb/lp0", also as CUPS "file:", "parallel:"
+// and "serial:" URIs. Port 9100 is used, if socket URI has no port.
+func OpenDevice(uri string) (*Printer, error) {
+	if strings.HasPrefix(uri, "socket://") {
+		addr := strings.TrimSuffix(strings.TrimPrefix(uri, "socket://"), "/")
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			addr = net.JoinHostPort(addr, "9100")
+		}
+		c, err := net.Dial("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		return &Printer{dev: c}, nil
+	}
+	path := uri
+	for _, scheme := range []string{"file:", "parallel:", "serial:"} {
+		if strings.HasPrefix(uri, scheme) {
+			path = strings.TrimPrefix(uri[len(scheme):], "//")
+			break
+		}
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("printer: unsupported device URI %q", uri)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Printer{dev: f}, nil
+}
+
+// Write sends data b to printer p.
+func (p *Printer) Write(b []byte) (int, error) {
+	return p.dev.Write(b)
+}
+
+// SetReadDeadline sets deadline for future Read calls. Zero t
+// means Read does not time out. Device files, that do not support
+// deadlines, return os.ErrNoDeadline.
+func (p *Printer) SetReadDeadline(t time.Time) error {
+	return p.dev.SetReadDeadline(t)
+}
+
+// Read reads data sent back by printer p, like PJL or ESC/POS
+// status replies. Read waits until printer sends some data. If
+// read deadline (see SetReadDeadline) passes first, Read returns
+// os.ErrDeadlineExceeded. Read returns io.EOF, if printer closed
+// the connection.
+func (p *Printer) Read(b []byte) (int, error) {
+	n, err := p.dev.Read(b)
+	if err != nil && errors.Is(err, os.ErrDeadlineExceeded) {
+		err = os.ErrDeadlineExceeded
+	}
+	return n, err
+}
+
+// Close closes printer p. Pending Read call returns error.
+func (p *Printer) Close() error {
+	return p.dev.Close()
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package printer
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDeviceSocket(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	reply := make(chan string)
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		line, _ := bufio.NewReader(c).ReadString('\n')
+		c.Write([]byte(<-reply + line))
+		// Wait for client to close the connection.
+		c.Read(make([]byte, 1))
+	}()
+
+	p, err := OpenDevice("socket://" + l.Addr().String())
+	if err != nil {
+		t.Fatalf("OpenDevice failed: %v", err)
+	}
+	defer p.Close()
+	if _, err := p.Write([]byte("@PJL INFO STATUS\r\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	buf := make([]byte, 100)
+	p.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	if n, err := p.Read(buf); n != 0 || err != os.ErrDeadlineExceeded {
+		t.Fatalf("Read returned %d, %v, want 0, %v", n, err, os.ErrDeadlineExceeded)
+	}
+	reply <- "ok "
+	p.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := p.Read(buf)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if got, want := string(buf[:n]), "ok @PJL INFO STATUS\r\n"; got != want {
+		t.Errorf("Read returned %q, want %q", got, want)
+	}
+
+	// Close interrupts pending Read.
+	p.SetReadDeadline(time.Time{})
+	done := make(chan error)
+	go func() {
+		_, err := p.Read(buf)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	p.Close()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Read after Close succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not interrupt Read")
+	}
+}
+
+func TestDeviceFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "printer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "lp0")
+	if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := OpenDevice("file://" + path)
+	if err != nil {
+		t.Fatalf("OpenDevice failed: %v", err)
+	}
+	if _, err := p.Write([]byte("\x1b@hello")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "\x1b@hello" {
+		t.Errorf("device file has %q", b)
+	}
+	for _, uri := range []string{"usb://HP/LaserJet", "lp0", "ipp://server/printers/laser"} {
+		if _, err := OpenDevice(uri); err == nil {
+			t.Errorf("OpenDevice(%q) succeeded", uri)
+		}
+	}
+}
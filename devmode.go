@@ -0,0 +1,136 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+// DEVMODE dmFields flags.
+const (
+	DM_ORIENTATION   = 0x00000001
+	DM_PAPERSIZE     = 0x00000002
+	DM_PAPERLENGTH   = 0x00000004
+	DM_PAPERWIDTH    = 0x00000008
+	DM_SCALE         = 0x00000010
+	DM_COPIES        = 0x00000100
+	DM_DEFAULTSOURCE = 0x00000200
+	DM_PRINTQUALITY  = 0x00000400
+	DM_COLOR         = 0x00000800
+	DM_DUPLEX        = 0x00001000
+	DM_YRESOLUTION   = 0x00002000
+	DM_TTOPTION      = 0x00004000
+	DM_COLLATE       = 0x00008000
+	DM_FORMNAME      = 0x00010000
+	DM_MEDIATYPE     = 0x02000000
+)
+
+const (
+	DMORIENT_PORTRAIT  = 1
+	DMORIENT_LANDSCAPE = 2
+
+	DMCOLOR_MONOCHROME = 1
+	DMCOLOR_COLOR      = 2
+
+	DMDUP_SIMPLEX    = 1
+	DMDUP_VERTICAL   = 2
+	DMDUP_HORIZONTAL = 3
+
+	DMCOLLATE_FALSE = 0
+	DMCOLLATE_TRUE  = 1
+)
+
+// DevMode stores printer settings of Windows DEVMODE structure.
+type DevMode struct {
+	DeviceName    string
+	Fields        uint32 // combination of DM_* flags of fields that are set
+	Orientation   int16  // DMORIENT_*
+	PaperSize     int16  // DMPAPER_*
+	PaperLength   int16  // in tenths of a millimeter
+	PaperWidth    int16  // in tenths of a millimeter
+	Scale         int16  // in percent
+	Copies        int16
+	DefaultSource int16
+	PrintQuality  int16 // DMRES_* or dpi
+	Color         int16 // DMCOLOR_*
+	Duplex        int16 // DMDUP_*
+	YResolution   int16
+	Collate       int16 // DMCOLLATE_*
+	FormName      string
+	MediaType     uint32
+	Raw           []byte // complete DEVMODE including driver private data
+}
+
+// devModeSize is size of DEVMODEW structure without driver private data.
+const devModeSize = 220
+
+// ParseDevMode decodes DEVMODEW structure stored in b.
+// DEVMODE public part might be shorter than current DEVMODEW
+// structure, if it was created by older driver.
+func ParseDevMode(b []byte) (*DevMode, error) {
+	if len(b) < 72 {
+		return nil, errors.New("printer: DEVMODE is too short")
+	}
+	le := binary.LittleEndian
+	size := int(le.Uint16(b[68:]))
+	extra := int(le.Uint16(b[70:]))
+	if size < 72 || size+extra > len(b) {
+		return nil, errors.New("printer: invalid DEVMODE size")
+	}
+	pub := make([]byte, devModeSize)
+	copy(pub, b[:size])
+	i16 := func(off int) int16 { return int16(le.Uint16(pub[off:])) }
+	dm := &DevMode{
+		DeviceName:    utf16BytesToString(pub[:64]),
+		Fields:        le.Uint32(pub[72:]),
+		Orientation:   i16(76),
+		PaperSize:     i16(78),
+		PaperLength:   i16(80),
+		PaperWidth:    i16(82),
+		Scale:         i16(84),
+		Copies:        i16(86),
+		DefaultSource: i16(88),
+		PrintQuality:  i16(90),
+		Color:         i16(92),
+		Duplex:        i16(94),
+		YResolution:   i16(96),
+		Collate:       i16(100),
+		FormName:      utf16BytesToString(pub[102:166]),
+		MediaType:     le.Uint32(pub[196:]),
+		Raw:           append([]byte(nil), b[:size+extra]...),
+	}
+	return dm, nil
+}
+
+// utf16BytesToString converts NUL terminated little endian
+// UTF-16 string stored in b into string.
+func utf16BytesToString(b []byte) string {
+	s := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			break
+		}
+		s = append(s, c)
+	}
+	return string(utf16.Decode(s))
+}
+
+// Media returns paper size selected by d. It uses PaperLength and
+// PaperWidth, if set, and PaperSize otherwise.
+func (d *DevMode) Media() (media.Size, bool) {
+	if d.Fields&(DM_PAPERLENGTH|DM_PAPERWIDTH) == DM_PAPERLENGTH|DM_PAPERWIDTH &&
+		d.PaperLength > 0 && d.PaperWidth > 0 {
+		return media.LookupSize(int(d.PaperWidth)*10, int(d.PaperLength)*10, 100)
+	}
+	if d.Fields&DM_PAPERSIZE != 0 {
+		return media.LookupDMPaper(int(d.PaperSize))
+	}
+	return media.Size{}, false
+}
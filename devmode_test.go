@@ -0,0 +1,82 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+)
+
+// makeDevMode returns DEVMODEW structure of size bytes followed
+// by extra bytes of driver private data.
+func makeDevMode(size, extra int) []byte {
+	b := make([]byte, size+extra)
+	le := binary.LittleEndian
+	putString := func(off int, s string) {
+		for i, c := range utf16.Encode([]rune(s)) {
+			le.PutUint16(b[off+2*i:], c)
+		}
+	}
+	putString(0, "HP LaserJet")
+	le.PutUint16(b[64:], 0x0401) // dmSpecVersion
+	le.PutUint16(b[68:], uint16(size))
+	le.PutUint16(b[70:], uint16(extra))
+	le.PutUint32(b[72:], DM_ORIENTATION|DM_PAPERSIZE|DM_COPIES|DM_DUPLEX|DM_COLOR|DM_FORMNAME|DM_COLLATE)
+	le.PutUint16(b[76:], DMORIENT_LANDSCAPE)
+	le.PutUint16(b[78:], 9) // DMPAPER_A4
+	le.PutUint16(b[86:], 3)
+	le.PutUint16(b[92:], DMCOLOR_MONOCHROME)
+	le.PutUint16(b[94:], DMDUP_VERTICAL)
+	le.PutUint16(b[100:], DMCOLLATE_TRUE)
+	putString(102, "A4")
+	if size >= 200 {
+		le.PutUint32(b[196:], 1) // DMMEDIA_STANDARD
+	}
+	for i := size; i < len(b); i++ {
+		b[i] = 0xAA
+	}
+	return b
+}
+
+func TestParseDevMode(t *testing.T) {
+	// Older drivers create shorter DEVMODE.
+	for _, size := range []int{devModeSize, 168} {
+		b := makeDevMode(size, 16)
+		dm, err := ParseDevMode(b)
+		if err != nil {
+			t.Fatalf("ParseDevMode failed: %v", err)
+		}
+		if dm.DeviceName != "HP LaserJet" || dm.FormName != "A4" {
+			t.Errorf("unexpected names: %q and %q", dm.DeviceName, dm.FormName)
+		}
+		if dm.Orientation != DMORIENT_LANDSCAPE || dm.PaperSize != 9 || dm.Copies != 3 ||
+			dm.Color != DMCOLOR_MONOCHROME || dm.Duplex != DMDUP_VERTICAL || dm.Collate != DMCOLLATE_TRUE {
+			t.Errorf("unexpected DevMode: %+v", dm)
+		}
+		wantMediaType := uint32(0)
+		if size >= 200 {
+			wantMediaType = 1
+		}
+		if dm.MediaType != wantMediaType {
+			t.Errorf("MediaType is %d, want %d", dm.MediaType, wantMediaType)
+		}
+		if len(dm.Raw) != size+16 || dm.Raw[len(dm.Raw)-1] != 0xAA {
+			t.Errorf("Raw has unexpected content %x", dm.Raw)
+		}
+		m, ok := dm.Media()
+		if !ok || m.Name != "iso_a4_210x297mm" {
+			t.Errorf("Media returned %q, %v", m.Name, ok)
+		}
+	}
+
+	b := makeDevMode(devModeSize, 0)
+	if _, err := ParseDevMode(b[:70]); err == nil {
+		t.Errorf("ParseDevMode accepted short DEVMODE")
+	}
+	if _, err := ParseDevMode(b[:devModeSize-1]); err == nil {
+		t.Errorf("ParseDevMode accepted DEVMODE with invalid size")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"errors"
+	"io"
+	"syscall"
+)
+
+//sys	AbortPrinter(h syscall.Handle) (err error) = winspool.AbortPrinter
+
+var (
+	ErrDocumentClosed = errors.New("printer: document is closed")
+	ErrPageOpen       = errors.New("printer: previous page is not closed")
+	ErrPageClosed     = errors.New("printer: page is closed")
+)
+
+// DocOptions describes document created by NewDocument.
+type DocOptions struct {
+	// Datatype is document data type, like "RAW" or "XPS_PASS".
+	// If empty, it is chosen the same way StartRawDocument does.
+	Datatype string
+	// OutputFile is file name to print into. Empty OutputFile
+	// means the document is sent to the printer.
+	OutputFile string
+}
+
+type docState int
+
+const (
+	docOpen docState = iota
+	docPageOpen
+	docClosed
+)
+
+// Document is a print job started by NewDocument. Document pages
+// must be written one at a time, and document must be finished
+// with Close or Abort.
+type Document struct {
+	JobID uint32
+
+	p     *Printer
+	state docState
+	page  *page
+	err   error // first error writing document
+}
+
+// NewDocument starts new print job called name on printer p.
+// opts can be nil.
+func (p *Printer) NewDocument(name string, opts *DocOptions) (*Document, error) {
+	if opts == nil {
+		opts = &DocOptions{}
+	}
+	datatype := opts.Datatype
+	if datatype == "" {
+		var err error
+		datatype, err = p.rawDatatype()
+		if err != nil {
+			return nil, err
+		}
+	}
+	d := DOC_INFO_1{
+		DocName:    &(syscall.StringToUTF16(name))[0],
+		OutputFile: stringToUTF16Ptr(opts.OutputFile),
+		Datatype:   &(syscall.StringToUTF16(datatype))[0],
+	}
+	id, err := StartDocPrinter(p.h, 1, &d)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{JobID: id, p: p}, nil
+}
+
+// setErr remembers err, if it is the first document error.
+func (d *Document) setErr(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// NewPage starts new document page. Previous page must be closed.
+// Page data is written with Write, and page is finished with Close.
+func (d *Document) NewPage() (io.WriteCloser, error) {
+	switch d.state {
+	case docPageOpen:
+		return nil, ErrPageOpen
+	case docClosed:
+		return nil, ErrDocumentClosed
+	}
+	err := StartPagePrinter(d.p.h)
+	if err != nil {
+		d.setErr(err)
+		return nil, err
+	}
+	d.state = docPageOpen
+	d.page = &page{d: d}
+	return d.page, nil
+}
+
+// Abort deletes the print job.
+func (d *Document) Abort() error {
+	if d.state == docClosed {
+		return ErrDocumentClosed
+	}
+	d.state = docClosed
+	if d.page != nil {
+		d.page.closed = true
+		d.page = nil
+	}
+	return AbortPrinter(d.p.h)
+}
+
+// Close closes current page, if it is still open, and finishes
+// the print job. It returns the first error encountered while
+// writing the document.
+func (d *Document) Close() error {
+	if d.state == docClosed {
+		return ErrDocumentClosed
+	}
+	if d.state == docPageOpen {
+		d.page.Close()
+	}
+	d.state = docClosed
+	err := EndDocPrinter(d.p.h)
+	if err != nil {
+		d.setErr(err)
+	}
+	return d.err
+}
+
+// page is a Document page returned by NewPage.
+type page struct {
+	d      *Document
+	closed bool
+}
+
+func (pg *page) Write(b []byte) (int, error) {
+	if pg.closed {
+		return 0, ErrPageClosed
+	}
+	n, err := pg.d.p.Write(b)
+	if err != nil {
+		pg.d.setErr(err)
+	}
+	return n, err
+}
+
+func (pg *page) Close() error {
+	if pg.closed {
+		return ErrPageClosed
+	}
+	pg.closed = true
+	pg.d.state = docOpen
+	pg.d.page = nil
+	err := EndPagePrinter(pg.d.p.h)
+	if err != nil {
+		pg.d.setErr(err)
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	d, err := p.NewDocument("my document", &DocOptions{Datatype: "RAW"})
+	if err != nil {
+		t.Fatalf("NewDocument failed: %v", err)
+	}
+	if d.JobID == 0 {
+		t.Errorf("NewDocument returned zero JobID")
+	}
+	for i := 1; i <= 2; i++ {
+		page, err := d.NewPage()
+		if err != nil {
+			t.Fatalf("NewPage failed: %v", err)
+		}
+		if _, err := d.NewPage(); err != ErrPageOpen {
+			t.Fatalf("second NewPage returned %v, want %v", err, ErrPageOpen)
+		}
+		fmt.Fprintf(page, "Hello %q page %d\n", name, i)
+		err = page.Close()
+		if err != nil {
+			t.Fatalf("page Close failed: %v", err)
+		}
+		if _, err := page.Write([]byte("more")); err != ErrPageClosed {
+			t.Fatalf("Write after page Close returned %v, want %v", err, ErrPageClosed)
+		}
+	}
+	err = d.Close()
+	if err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := d.NewPage(); err != ErrDocumentClosed {
+		t.Fatalf("NewPage after Close returned %v, want %v", err, ErrDocumentClosed)
+	}
+	if err := d.Close(); err != ErrDocumentClosed {
+		t.Fatalf("second Close returned %v, want %v", err, ErrDocumentClosed)
+	}
+}
+
+func TestDocumentAbort(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	d, err := p.NewDocument("aborted document", nil)
+	if err != nil {
+		t.Fatalf("NewDocument failed: %v", err)
+	}
+	page, err := d.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage failed: %v", err)
+	}
+	fmt.Fprintf(page, "This should not be printed\n")
+	err = d.Abort()
+	if err != nil {
+		t.Fatalf("Abort failed: %v", err)
+	}
+	if _, err := page.Write([]byte("more")); err != ErrPageClosed {
+		t.Fatalf("Write after Abort returned %v, want %v", err, ErrPageClosed)
+	}
+	if err := d.Close(); err != ErrDocumentClosed {
+		t.Fatalf("Close after Abort returned %v, want %v", err, ErrDocumentClosed)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package epl builds EPL2 labels for Eltron and older Zebra label
+// printers. The result can be sent to the printer as raw data, for
+// example with printer StartRawDocument and Write.
+//
+// All positions and sizes are in printer dots.
+package epl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+// Rotation is field rotation.
+type Rotation int
+
+const (
+	Rotate0   Rotation = 0
+	Rotate90  Rotation = 1
+	Rotate180 Rotation = 2
+	Rotate270 Rotation = 3
+)
+
+// Barcode types.
+const (
+	Code128 = "1"   // Code 128 with automatic subset selection
+	Code39  = "3"   // Code 39
+	EAN13   = "E30" // EAN-13
+	UPCA    = "UA0" // UPC-A
+)
+
+// Builder builds EPL2 commands. Builder methods append commands
+// to the stream, and the result is returned by Bytes or written
+// by WriteTo. Every label must be started with Start and
+// finished with Print.
+type Builder struct {
+	buf bytes.Buffer
+}
+
+// New returns new empty Builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Bytes returns commands built so far.
+func (b *Builder) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// WriteTo writes commands built so far to w.
+func (b *Builder) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.buf.Bytes())
+	return int64(n), err
+}
+
+// cmd appends single command line.
+func (b *Builder) cmd(format string, args ...interface{}) {
+	fmt.Fprintf(&b.buf, format, args...)
+	b.buf.WriteByte('\n')
+}
+
+// quote returns s as EPL2 quoted string.
+func quote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ", "\r", " ").Replace(s)
+	return `"` + s + `"`
+}
+
+// Start starts new label by clearing image buffer (N). Leading empty
+// line terminates any incomplete command sent before.
+func (b *Builder) Start() {
+	b.buf.WriteByte('\n')
+	b.cmd("N")
+}
+
+// LabelSize sets label width (q), and label length and gap
+// between labels (Q).
+func (b *Builder) LabelSize(width, length, gap int) {
+	b.cmd("q%d", width)
+	b.cmd("Q%d,%d", length, gap)
+}
+
+// Text prints ASCII text s with font 1 to 5 magnified hmul times
+// horizontally and vmul times vertically (A). reverse prints
+// white text on black background.
+func (b *Builder) Text(x, y int, r Rotation, font, hmul, vmul int, reverse bool, s string) {
+	rev := 'N'
+	if reverse {
+		rev = 'R'
+	}
+	b.cmd("A%d,%d,%d,%d,%d,%d,%c,%s", x, y, r, font, hmul, vmul, rev, quote(s))
+}
+
+// Barcode prints barcode of type typ, like Code128, with narrow and
+// wide bars of the given width and height (B). hri prints human
+// readable text below the barcode.
+func (b *Builder) Barcode(x, y int, r Rotation, typ string, narrow, wide, height int, hri bool, data string) {
+	h := 'N'
+	if hri {
+		h = 'B'
+	}
+	b.cmd("B%d,%d,%d,%s,%d,%d,%d,%c,%s", x, y, r, typ, narrow, wide, height, h, quote(data))
+}
+
+// QR prints QR code model 2 with module size of scale dots and error
+// correction level 'L', 'M', 'Q' or 'H' (b).
+func (b *Builder) QR(x, y, scale int, level byte, data string) {
+	b.cmd("b%d,%d,Q,m2,s%d,e%c,%s", x, y, scale, level, quote(data))
+}
+
+// Line draws filled black rectangle (LO).
+func (b *Builder) Line(x, y, width, height int) {
+	b.cmd("LO%d,%d,%d,%d", x, y, width, height)
+}
+
+// Box draws rectangle border thickness dots thick (X).
+func (b *Builder) Box(x, y, width, height, thickness int) {
+	b.cmd("X%d,%d,%d,%d,%d", x, y, thickness, x+width, y+height)
+}
+
+// Bitmap prints bitmap bm at x, y (GW).
+func (b *Builder) Bitmap(x, y int, bm *bitmap.Bitmap) {
+	fmt.Fprintf(&b.buf, "GW%d,%d,%d,%d,", x, y, bm.Stride, bm.Height)
+	// EPL2 prints cleared bits as black dots.
+	data := make([]byte, len(bm.Pix))
+	for i, c := range bm.Pix {
+		data[i] = ^c
+	}
+	b.buf.Write(data)
+	b.buf.WriteByte('\n')
+}
+
+// Print prints the label copies times (P).
+func (b *Builder) Print(copies int) {
+	b.cmd("P%d", copies)
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package epl
+
+import (
+	"testing"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+func TestBuilder(t *testing.T) {
+	bm := bitmap.New(10, 2)
+	bm.Set(0, 0, true)
+	b := New()
+	b.Start()
+	b.LabelSize(812, 1218, 24)
+	b.Text(50, 60, Rotate90, 3, 2, 2, true, `say "hi" \o/`)
+	b.Barcode(50, 200, Rotate0, Code128, 2, 6, 100, true, "ABC123")
+	b.QR(400, 200, 5, 'H', "data")
+	b.Line(0, 0, 812, 4)
+	b.Box(10, 10, 100, 50, 3)
+	b.Bitmap(5, 6, bm)
+	b.Print(2)
+	want := "\nN\nq812\nQ1218,24\n" +
+		"A50,60,1,3,2,2,R,\"say \\\"hi\\\" \\\\o/\"\n" +
+		"B50,200,0,1,2,6,100,B,\"ABC123\"\n" +
+		"b400,200,Q,m2,s5,eH,\"data\"\n" +
+		"LO0,0,812,4\n" +
+		"X10,10,3,110,60\n" +
+		"GW5,6,2,2,\x7f\xff\xff\xff\n" +
+		"P2\n"
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("unexpected EPL2:\n%q\nwant\n%q", got, want)
+	}
+}
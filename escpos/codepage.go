@@ -0,0 +1,102 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package escpos
+
+// CodePage is printer character code table. CodePage value is
+// the table number used by ESC t command.
+type CodePage int
+
+const (
+	PC437   CodePage = 0  // USA, standard Europe
+	PC850   CodePage = 2  // multilingual
+	WPC1252 CodePage = 16 // Windows Latin-1
+	PC866   CodePage = 17 // Cyrillic
+	PC858   CodePage = 19 // multilingual with euro sign
+)
+
+// Upper halves (characters 0x80 - 0xff) of code pages.
+// Unused codes are marked with U+FFFD.
+var codePages = map[CodePage]string{
+	PC437: "ÇüéâäàåçêëèïîìÄÅ" +
+		"ÉæÆôöòûùÿÖÜ¢£¥₧ƒ" +
+		"áíóúñÑªº¿⌐¬½¼¡«»" +
+		"░▒▓│┤╡╢╖╕╣║╗╝╜╛┐" +
+		"└┴┬├─┼╞╟╚╔╩╦╠═╬╧" +
+		"╨╤╥╙╘╒╓╫╪┘┌█▄▌▐▀" +
+		"αßΓπΣσµτΦΘΩδ∞φε∩" +
+		"≡±≥≤⌠⌡÷≈°∙·√ⁿ²■\u00a0",
+	PC850: "ÇüéâäàåçêëèïîìÄÅ" +
+		"ÉæÆôöòûùÿÖÜø£Ø×ƒ" +
+		"áíóúñÑªº¿®¬½¼¡«»" +
+		"░▒▓│┤ÁÂÀ©╣║╗╝¢¥┐" +
+		"└┴┬├─┼ãÃ╚╔╩╦╠═╬¤" +
+		"ðÐÊËÈıÍÎÏ┘┌█▄¦Ì▀" +
+		"ÓßÔÒõÕµþÞÚÛÙýÝ¯´" +
+		"\u00ad±‗¾¶§÷¸°¨·¹³²■\u00a0",
+	WPC1252: "€�‚ƒ„…†‡ˆ‰Š‹Œ�Ž�" +
+		"�‘’“”•–—˜™š›œ�žŸ" +
+		"\u00a0¡¢£¤¥¦§¨©ª«¬\u00ad®¯" +
+		"°±²³´µ¶·¸¹º»¼½¾¿" +
+		"ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏ" +
+		"ÐÑÒÓÔÕÖ×ØÙÚÛÜÝÞß" +
+		"àáâãäåæçèéêëìíîï" +
+		"ðñòóôõö÷øùúûüýþÿ",
+	PC866: "АБВГДЕЖЗИЙКЛМНОП" +
+		"РСТУФХЦЧШЩЪЫЬЭЮЯ" +
+		"абвгдежзийклмноп" +
+		"░▒▓│┤╡╢╖╕╣║╗╝╜╛┐" +
+		"└┴┬├─┼╞╟╚╔╩╦╠═╬╧" +
+		"╨╤╥╙╘╒╓╫╪┘┌█▄▌▐▀" +
+		"рстуфхцчшщъыьэюя" +
+		"ЁёЄєЇїЎў°∙·√№¤■\u00a0",
+	PC858: "ÇüéâäàåçêëèïîìÄÅ" +
+		"ÉæÆôöòûùÿÖÜø£Ø×ƒ" +
+		"áíóúñÑªº¿®¬½¼¡«»" +
+		"░▒▓│┤ÁÂÀ©╣║╗╝¢¥┐" +
+		"└┴┬├─┼ãÃ╚╔╩╦╠═╬¤" +
+		"ðÐÊËÈ€ÍÎÏ┘┌█▄¦Ì▀" +
+		"ÓßÔÒõÕµþÞÚÛÙýÝ¯´" +
+		"\u00ad±‗¾¶§÷¸°¨·¹³²■\u00a0",
+}
+
+// encoders maps code page characters to their codes.
+var encoders = make(map[CodePage]map[rune]byte)
+
+func init() {
+	for cp, s := range codePages {
+		m := make(map[rune]byte)
+		c := 0x80
+		for _, r := range s {
+			if r != '�' {
+				m[r] = byte(c)
+			}
+			c++
+		}
+		if c != 0x100 {
+			panic("escpos: code page table has wrong size")
+		}
+		encoders[cp] = m
+	}
+}
+
+// encode returns s converted to code page cp. Characters that
+// do not fit are replaced with '?'. Control characters, other than
+// line feed and tab, are removed, so s cannot contain commands.
+func encode(s string, cp CodePage) []byte {
+	m := encoders[cp]
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r < 0x20 && r != '\n' && r != '\t', r == 0x7f:
+		case r < 0x80:
+			b = append(b, byte(r))
+		case m[r] != 0:
+			b = append(b, m[r])
+		default:
+			b = append(b, '?')
+		}
+	}
+	return b
+}
@@ -0,0 +1,372 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package escpos builds ESC/POS command streams for Epson and compatible
+// receipt printers. The result can be sent to the printer as raw data,
+// for example with printer StartRawDocument and Write.
+package escpos
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+const (
+	esc = 0x1b
+	gs  = 0x1d
+)
+
+// Align is text, barcode and image alignment.
+type Align byte
+
+const (
+	Left   Align = 0
+	Center Align = 1
+	Right  Align = 2
+)
+
+// Underline is underline mode.
+type Underline byte
+
+const (
+	UnderlineNone  Underline = 0
+	UnderlineThin  Underline = 1 // one dot thick
+	UnderlineThick Underline = 2 // two dots thick
+)
+
+// Builder builds ESC/POS command stream. Builder methods append
+// commands to the stream, and the result is returned by Bytes
+// or written by WriteTo.
+type Builder struct {
+	buf      bytes.Buffer
+	codePage CodePage
+}
+
+// New returns new Builder. The stream starts with printer
+// initialization command, so the printer is in known state.
+func New() *Builder {
+	b := &Builder{}
+	b.Init()
+	return b
+}
+
+// Bytes returns command stream built so far.
+func (b *Builder) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// WriteTo writes command stream built so far to w.
+func (b *Builder) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.buf.Bytes())
+	return int64(n), err
+}
+
+// Raw appends data to the stream as is.
+func (b *Builder) Raw(data []byte) {
+	b.buf.Write(data)
+}
+
+// Init resets printer settings (ESC @). Code page is reset to PC437.
+func (b *Builder) Init() {
+	b.buf.Write([]byte{esc, '@'})
+	b.codePage = PC437
+}
+
+// SetCodePage selects code page used to print text (ESC t).
+func (b *Builder) SetCodePage(cp CodePage) {
+	b.buf.Write([]byte{esc, 't', byte(cp)})
+	b.codePage = cp
+}
+
+// Text prints s converted to current code page. Characters that
+// do not fit the code page are printed as '?', and control characters,
+// other than line feed and tab, are removed. Text is printed when
+// the line is full or when line feed is sent, for example with Line.
+func (b *Builder) Text(s string) {
+	b.buf.Write(encode(s, b.codePage))
+}
+
+// Line prints s followed by line feed.
+func (b *Builder) Line(s string) {
+	b.Text(s)
+	b.buf.WriteByte('\n')
+}
+
+// Feed prints buffered text and feeds paper n lines (ESC d).
+func (b *Builder) Feed(n int) {
+	b.buf.Write([]byte{esc, 'd', clamp(n, 0, 255)})
+}
+
+func clamp(n, min, max int) byte {
+	if n < min {
+		return byte(min)
+	}
+	if n > max {
+		return byte(max)
+	}
+	return byte(n)
+}
+
+func boolByte(v bool) byte {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// Bold turns emphasized mode on or off (ESC E).
+func (b *Builder) Bold(on bool) {
+	b.buf.Write([]byte{esc, 'E', boolByte(on)})
+}
+
+// Underline sets underline mode (ESC -).
+func (b *Builder) Underline(u Underline) {
+	b.buf.Write([]byte{esc, '-', byte(u)})
+}
+
+// Inverse turns white on black printing on or off (GS B).
+func (b *Builder) Inverse(on bool) {
+	b.buf.Write([]byte{gs, 'B', boolByte(on)})
+}
+
+// Size sets character width and height magnification (GS !).
+// Values are from 1 (normal size) to 8.
+func (b *Builder) Size(width, height int) {
+	b.buf.Write([]byte{gs, '!', (clamp(width, 1, 8)-1)<<4 | (clamp(height, 1, 8) - 1)})
+}
+
+// Align sets alignment of the following lines (ESC a). Alignment
+// only takes effect at the beginning of the line.
+func (b *Builder) Align(a Align) {
+	b.buf.Write([]byte{esc, 'a', byte(a)})
+}
+
+// Cut feeds paper to the cutter and cuts it fully or
+// leaving one point uncut (GS V).
+func (b *Builder) Cut(partial bool) {
+	m := byte(65)
+	if partial {
+		m = 66
+	}
+	b.buf.Write([]byte{gs, 'V', m, 0})
+}
+
+// KickDrawer sends pulse to cash drawer kick-out connector pin 2 or
+// pin 5 (ESC p). The pulse is 50 ms on and 500 ms off.
+func (b *Builder) KickDrawer(pin int) {
+	m := byte(0)
+	if pin == 5 {
+		m = 1
+	}
+	b.buf.Write([]byte{esc, 'p', m, 25, 250})
+}
+
+// BarcodeType is 1D barcode symbology. BarcodeType value is
+// the symbology number used by GS k command.
+type BarcodeType byte
+
+const (
+	UPCA    BarcodeType = 65
+	UPCE    BarcodeType = 66
+	EAN13   BarcodeType = 67
+	EAN8    BarcodeType = 68
+	Code39  BarcodeType = 69
+	ITF     BarcodeType = 70
+	Codabar BarcodeType = 71
+	Code93  BarcodeType = 72
+	Code128 BarcodeType = 73
+)
+
+// HRI is barcode human readable interpretation position.
+type HRI byte
+
+const (
+	HRINone  HRI = 0
+	HRIAbove HRI = 1
+	HRIBelow HRI = 2
+	HRIBoth  HRI = 3
+)
+
+// BarcodeOptions describes how barcode is printed.
+type BarcodeOptions struct {
+	Height int // in dots; 162, if zero
+	Width  int // module width from 2 to 6 dots; 3, if zero
+	HRI    HRI
+}
+
+var (
+	ErrBarcodeData = errors.New("escpos: invalid barcode data")
+	ErrQRData      = errors.New("escpos: invalid QR code data")
+)
+
+// checkBarcode verifies that data can be encoded with barcode type t.
+func checkBarcode(t BarcodeType, data string) bool {
+	digits := func(min, max int) bool {
+		if len(data) < min || len(data) > max {
+			return false
+		}
+		for _, c := range data {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+		return true
+	}
+	switch t {
+	case UPCA:
+		return digits(11, 12)
+	case UPCE:
+		return digits(6, 12)
+	case EAN13:
+		return digits(12, 13)
+	case EAN8:
+		return digits(7, 8)
+	case ITF:
+		return digits(2, 255) && len(data)%2 == 0
+	case Code39:
+		if len(data) == 0 || len(data) > 255 {
+			return false
+		}
+		for _, c := range data {
+			if !strings.ContainsRune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ -.$/+%*", c) {
+				return false
+			}
+		}
+		return true
+	case Codabar:
+		if len(data) < 2 || len(data) > 255 {
+			return false
+		}
+		for _, c := range data {
+			if !strings.ContainsRune("0123456789ABCDabcd$+-./:", c) {
+				return false
+			}
+		}
+		return true
+	case Code93, Code128:
+		if len(data) == 0 || len(data) > 253 {
+			return false
+		}
+		for _, c := range data {
+			if c > 0x7f {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Barcode prints 1D barcode t with data (GS k). Code 128 data
+// that does not start with code set selection "{A", "{B" or "{C" is
+// printed with code set B, and its "{" characters are escaped as "{{".
+func (b *Builder) Barcode(t BarcodeType, data string, opts *BarcodeOptions) error {
+	if !checkBarcode(t, data) {
+		return ErrBarcodeData
+	}
+	if t == Code128 && !hasCodeSet(data) {
+		data = "{B" + strings.ReplaceAll(data, "{", "{{")
+		if len(data) > 255 {
+			return ErrBarcodeData
+		}
+	}
+	if opts == nil {
+		opts = &BarcodeOptions{}
+	}
+	height := opts.Height
+	if height == 0 {
+		height = 162
+	}
+	width := opts.Width
+	if width == 0 {
+		width = 3
+	}
+	b.buf.Write([]byte{gs, 'h', clamp(height, 1, 255)})
+	b.buf.Write([]byte{gs, 'w', clamp(width, 2, 6)})
+	b.buf.Write([]byte{gs, 'H', byte(opts.HRI)})
+	b.buf.Write([]byte{gs, 'k', byte(t), byte(len(data))})
+	b.buf.WriteString(data)
+	return nil
+}
+
+// hasCodeSet reports, if Code 128 data starts with code set selection.
+func hasCodeSet(data string) bool {
+	return strings.HasPrefix(data, "{A") || strings.HasPrefix(data, "{B") || strings.HasPrefix(data, "{C")
+}
+
+// QRLevel is QR code error correction level.
+type QRLevel byte
+
+const (
+	QRLevelL QRLevel = 48 // recovers 7% of data
+	QRLevelM QRLevel = 49 // recovers 15% of data
+	QRLevelQ QRLevel = 50 // recovers 25% of data
+	QRLevelH QRLevel = 51 // recovers 30% of data
+)
+
+// QROptions describes how QR code is printed.
+type QROptions struct {
+	ModuleSize int     // module size from 1 to 16 dots; 3, if zero
+	Level      QRLevel // QRLevelM, if zero
+}
+
+// qrFunc appends GS ( k command for QR code function fn with data.
+func (b *Builder) qrFunc(fn byte, data ...byte) {
+	n := len(data) + 2
+	b.buf.Write([]byte{gs, '(', 'k', byte(n), byte(n >> 8), 49, fn})
+	b.buf.Write(data)
+}
+
+// QR prints QR code model 2 with data (GS ( k).
+func (b *Builder) QR(data []byte, opts *QROptions) error {
+	// Maximum data size of version 40 QR code.
+	if len(data) == 0 || len(data) > 7089 {
+		return ErrQRData
+	}
+	if opts == nil {
+		opts = &QROptions{}
+	}
+	size := opts.ModuleSize
+	if size == 0 {
+		size = 3
+	}
+	level := opts.Level
+	if level < QRLevelL || level > QRLevelH {
+		level = QRLevelM
+	}
+	b.qrFunc(65, 50, 0) // select model 2
+	b.qrFunc(67, clamp(size, 1, 16))
+	b.qrFunc(69, byte(level))
+	b.qrFunc(80, append([]byte{48}, data...)...) // store data
+	b.qrFunc(81, 48)                             // print
+	return nil
+}
+
+// maxImageBand is the maximum number of image rows sent with single
+// GS v 0 command. Many printers cannot buffer larger images.
+const maxImageBand = 256
+
+// Image prints image m converted to black and white with
+// dither method d (GS v 0). Image is printed one pixel per dot.
+func (b *Builder) Image(m image.Image, d bitmap.Dither) {
+	b.Bitmap(bitmap.FromImage(m, d))
+}
+
+// Bitmap prints bitmap bm one pixel per dot (GS v 0).
+func (b *Builder) Bitmap(bm *bitmap.Bitmap) {
+	for y := 0; y < bm.Height; y += maxImageBand {
+		h := bm.Height - y
+		if h > maxImageBand {
+			h = maxImageBand
+		}
+		b.buf.Write([]byte{gs, 'v', '0', 0,
+			byte(bm.Stride), byte(bm.Stride >> 8), byte(h), byte(h >> 8)})
+		b.buf.Write(bm.Pix[y*bm.Stride : (y+h)*bm.Stride])
+	}
+}
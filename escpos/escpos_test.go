@@ -0,0 +1,169 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package escpos
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+func TestBuilder(t *testing.T) {
+	b := New()
+	b.Align(Center)
+	b.Bold(true)
+	b.Size(2, 2)
+	b.Line("SHOP")
+	b.Size(1, 1)
+	b.Bold(false)
+	b.Align(Left)
+	b.Underline(UnderlineThin)
+	b.Inverse(true)
+	b.Text("x")
+	b.Inverse(false)
+	b.Underline(UnderlineNone)
+	b.Feed(3)
+	b.KickDrawer(5)
+	b.Cut(true)
+	want := "\x1b@" +
+		"\x1ba\x01\x1bE\x01\x1d!\x11SHOP\n\x1d!\x00\x1bE\x00\x1ba\x00" +
+		"\x1b-\x01\x1dB\x01x\x1dB\x00\x1b-\x00" +
+		"\x1bd\x03\x1bp\x01\x19\xfa\x1dVB\x00"
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("unexpected commands:\n%q\nwant\n%q", got, want)
+	}
+	var buf bytes.Buffer
+	n, err := b.WriteTo(&buf)
+	if err != nil || n != int64(len(want)) || buf.String() != want {
+		t.Errorf("WriteTo returned %d, %v", n, err)
+	}
+}
+
+func TestCodePage(t *testing.T) {
+	tests := []struct {
+		cp   CodePage
+		text string
+		want string
+	}{
+		{PC437, "Grüße ½ ☺", "\x1bt\x00Gr\x81\xe1e \xab ?"},
+		{PC850, "Ø€", "\x1bt\x02\x9d?"},
+		{PC858, "Ø€", "\x1bt\x13\x9d\xd5"},
+		{WPC1252, "€ “é”", "\x1bt\x10\x80 \x93\xe9\x94"},
+		{PC866, "Привет Ё", "\x1bt\x11\x8f\xe0\xa8\xa2\xa5\xe2 \xf0"},
+	}
+	for _, test := range tests {
+		b := &Builder{}
+		b.SetCodePage(test.cp)
+		b.Text(test.text)
+		if got := string(b.Bytes()); got != test.want {
+			t.Errorf("code page %d: %q encoded as %q, want %q", test.cp, test.text, got, test.want)
+		}
+	}
+	b := New()
+	b.SetCodePage(PC866)
+	b.Init()
+	b.Text("é")
+	if got := string(b.Bytes()); got != "\x1b@\x1bt\x11\x1b@\x82" {
+		t.Errorf("Init did not reset code page: %q", got)
+	}
+}
+
+func TestTextControl(t *testing.T) {
+	b := &Builder{}
+	b.Text("a\x1bi\x1dV\x00\x10\x14\x01\x7fb\tc\n")
+	if got, want := string(b.Bytes()), "aiVb\tc\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBarcode(t *testing.T) {
+	b := &Builder{}
+	if err := b.Barcode(EAN13, "400638133393", &BarcodeOptions{Height: 80, Width: 2, HRI: HRIBelow}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Barcode(Code128, "Ab-1", nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1dhP\x1dw\x02\x1dH\x02\x1dkC\x0c400638133393" +
+		"\x1dh\xa2\x1dw\x03\x1dH\x00\x1dkI\x06{BAb-1"
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("unexpected barcode commands:\n%q\nwant\n%q", got, want)
+	}
+	for _, test := range []struct{ data, want string }{
+		{"{C\x0c\x22", "{C\x0c\x22"},
+		{"{A12", "{A12"},
+		{"{x}", "{B{{x}"},
+		{"{", "{B{{"},
+	} {
+		c := &Builder{}
+		if err := c.Barcode(Code128, test.data, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := string(c.Bytes()[13:]); got != test.want {
+			t.Errorf("Code 128 data %q is sent as %q, want %q", test.data, got, test.want)
+		}
+	}
+	bad := []struct {
+		t    BarcodeType
+		data string
+	}{
+		{EAN13, "12345"},
+		{EAN13, "40063813339x"},
+		{ITF, "123"},
+		{Code39, "abc"},
+		{Code128, ""},
+		{Code128, "é"},
+		{Code128, strings.Repeat("{", 200)},
+		{BarcodeType(1), "1"},
+	}
+	for _, test := range bad {
+		if err := b.Barcode(test.t, test.data, nil); err != ErrBarcodeData {
+			t.Errorf("barcode %d with %q returned %v, want %v", test.t, test.data, err, ErrBarcodeData)
+		}
+	}
+}
+
+func TestQR(t *testing.T) {
+	b := &Builder{}
+	if err := b.QR([]byte("hi"), &QROptions{ModuleSize: 6, Level: QRLevelH}); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1d(k\x04\x001A2\x00" +
+		"\x1d(k\x03\x001C\x06" +
+		"\x1d(k\x03\x001E3" +
+		"\x1d(k\x05\x001P0hi" +
+		"\x1d(k\x03\x001Q0"
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("unexpected QR code commands:\n%q\nwant\n%q", got, want)
+	}
+	if err := b.QR(make([]byte, 8000), nil); err != ErrQRData {
+		t.Errorf("QR returned %v, want %v", err, ErrQRData)
+	}
+}
+
+func TestImage(t *testing.T) {
+	m := image.NewGray(image.Rect(0, 0, 10, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 10; x++ {
+			m.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	m.SetGray(0, 0, color.Gray{})
+	m.SetGray(9, 299, color.Gray{})
+	b := &Builder{}
+	b.Image(m, bitmap.Threshold)
+	got := b.Bytes()
+	band1 := append([]byte{0x1d, 'v', '0', 0, 2, 0, 0, 1, 0x80}, make([]byte, 511)...)
+	band2 := append([]byte{0x1d, 'v', '0', 0, 2, 0, 44, 0}, make([]byte, 87)...)
+	band2 = append(band2, 0x40)
+	want := append(band1, band2...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("unexpected image commands:\n%x\nwant\n%x", got, want)
+	}
+}
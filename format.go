@@ -0,0 +1,303 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// Format is document format.
+type Format int
+
+const (
+	FormatUnknown Format = iota
+	FormatText
+	FormatPDF
+	FormatPostScript
+	FormatPCL
+	FormatPCLXL
+	FormatZPL
+	FormatEPL
+	FormatESCPOS
+	FormatPWGRaster
+	FormatURF
+	FormatXPS
+	FormatJPEG
+	FormatPNG
+)
+
+var formatNames = [...]string{
+	FormatUnknown:    "unknown",
+	FormatText:       "text",
+	FormatPDF:        "PDF",
+	FormatPostScript: "PostScript",
+	FormatPCL:        "PCL",
+	FormatPCLXL:      "PCL XL",
+	FormatZPL:        "ZPL",
+	FormatEPL:        "EPL",
+	FormatESCPOS:     "ESC/POS",
+	FormatPWGRaster:  "PWG raster",
+	FormatURF:        "URF",
+	FormatXPS:        "XPS",
+	FormatJPEG:       "JPEG",
+	FormatPNG:        "PNG",
+}
+
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return formatNames[FormatUnknown]
+	}
+	return formatNames[f]
+}
+
+// MIMEType returns MIME type of format f, as used for IPP
+// document-format attribute. Printer languages without registered
+// MIME type are reported as "application/vnd.cups-raw", and unknown
+// format as "application/octet-stream".
+func (f Format) MIMEType() string {
+	switch f {
+	case FormatText:
+		return "text/plain"
+	case FormatPDF:
+		return "application/pdf"
+	case FormatPostScript:
+		return "application/postscript"
+	case FormatPCL:
+		return "application/vnd.hp-pcl"
+	case FormatPCLXL:
+		return "application/vnd.hp-pclxl"
+	case FormatZPL, FormatEPL, FormatESCPOS:
+		return "application/vnd.cups-raw"
+	case FormatPWGRaster:
+		return "image/pwg-raster"
+	case FormatURF:
+		return "image/urf"
+	case FormatXPS:
+		return "application/oxps"
+	case FormatJPEG:
+		return "image/jpeg"
+	case FormatPNG:
+		return "image/png"
+	}
+	return "application/octet-stream"
+}
+
+// sniffLen is number of bytes DetectFormat needs to detect format.
+const sniffLen = 4096
+
+// uel is PJL Universal Exit Language command.
+const uel = "\x1b%-12345X"
+
+// DetectFormat returns format of document that starts with data b.
+// It only looks at the first few kilobytes of b. PCL and PostScript
+// documents wrapped in PJL job are reported as the language PJL
+// switches the printer to.
+func DetectFormat(b []byte) Format {
+	if len(b) > sniffLen {
+		b = b[:sniffLen]
+	}
+	if bytes.HasPrefix(b, []byte(uel)) {
+		return detectPJL(b[len(uel):])
+	}
+	switch {
+	case bytes.HasPrefix(b, []byte("%PDF-")):
+		return FormatPDF
+	case bytes.HasPrefix(b, []byte("%!")),
+		bytes.HasPrefix(b, []byte("\x04%!")),
+		bytes.HasPrefix(b, []byte("\xc5\xd0\xd3\xc6")): // EPS with preview
+		return FormatPostScript
+	case len(b) >= 12 && bytes.IndexByte([]byte("'()"), b[0]) >= 0 && bytes.HasPrefix(b[1:], []byte(" HP-PCL XL;")):
+		return FormatPCLXL
+	case bytes.HasPrefix(b, []byte("RaS2")):
+		return FormatPWGRaster
+	case bytes.HasPrefix(b, []byte("UNIRAST\x00")):
+		return FormatURF
+	case bytes.HasPrefix(b, []byte("\xff\xd8\xff")):
+		return FormatJPEG
+	case bytes.HasPrefix(b, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG
+	case bytes.HasPrefix(b, []byte("PK\x03\x04")):
+		return detectZip(b)
+	case len(b) > 0 && b[0] == 0x1b:
+		return detectEscape(b)
+	case len(b) > 0 && (b[0] == 0x1c || b[0] == 0x1d):
+		// ESC/POS FS and GS commands.
+		return FormatESCPOS
+	}
+	if isZPL(b) {
+		return FormatZPL
+	}
+	if isEPL(b) {
+		return FormatEPL
+	}
+	if isText(b) {
+		return FormatText
+	}
+	return FormatUnknown
+}
+
+// pjlLanguages maps PJL ENTER LANGUAGE values to formats.
+var pjlLanguages = map[string]Format{
+	"PCL":        FormatPCL,
+	"PCLXL":      FormatPCLXL,
+	"POSTSCRIPT": FormatPostScript,
+	"PDF":        FormatPDF,
+}
+
+// detectPJL returns format of data b that follows UEL. Data starts
+// with PJL commands, if it is PJL job.
+func detectPJL(b []byte) Format {
+	for {
+		b = bytes.TrimLeft(b, "\r\n")
+		if len(b) < 4 || !bytes.EqualFold(b[:4], []byte("@PJL")) {
+			break
+		}
+		line := b
+		rest := []byte(nil)
+		if i := bytes.IndexByte(b, '\n'); i >= 0 {
+			line, rest = b[:i], b[i+1:]
+		}
+		f := bytes.Fields(bytes.ToUpper(line))
+		if len(f) >= 3 && string(f[1]) == "ENTER" && bytes.HasPrefix(f[2], []byte("LANGUAGE")) {
+			// Value is either separate field after "=", or joined with it.
+			v := bytes.TrimLeft(bytes.TrimPrefix(bytes.Join(f[2:], nil), []byte("LANGUAGE")), "=")
+			if lang, ok := pjlLanguages[string(v)]; ok {
+				return lang
+			}
+		}
+		if rest == nil {
+			// Incomplete PJL header.
+			return FormatPCL
+		}
+		b = rest
+	}
+	if len(b) == 0 || bytes.HasPrefix(b, []byte(uel)) {
+		// Printers use PCL, if PJL job does not choose language.
+		return FormatPCL
+	}
+	return DetectFormat(b)
+}
+
+// detectZip returns format of zip archive b. Only XPS documents are
+// recognized, by names of their parts.
+func detectZip(b []byte) Format {
+	for _, name := range []string{".fdseq", "FixedDocumentSequence", "FixedDocSeq", "Documents/1/"} {
+		if bytes.Contains(b, []byte(name)) {
+			return FormatXPS
+		}
+	}
+	return FormatUnknown
+}
+
+// detectEscape returns format of data b that starts with ESC. ESC E
+// resets PCL printer, but sets bold text on ESC/POS printer, so PCL
+// commands are recognized by their syntax.
+func detectEscape(b []byte) Format {
+	if len(b) < 2 {
+		return FormatUnknown
+	}
+	switch c := b[1]; {
+	case c == 'E':
+		if len(b) == 2 || b[2] == 0x1b {
+			return FormatPCL
+		}
+	case c == '%' && len(b) > 2 && (b[2] == '-' || b[2] >= '0' && b[2] <= '9'):
+		// Switch between PCL and HP-GL/2.
+		return FormatPCL
+	case c == '(' || c == ')':
+		// Symbol set selection, like ESC ( 1 0 U, or font
+		// selection, like ESC ( s 1 P.
+		if len(b) > 2 && b[2] >= '0' && b[2] <= '9' || isParameterized(b) {
+			return FormatPCL
+		}
+	case c >= '!' && c <= '/':
+		if isParameterized(b) {
+			return FormatPCL
+		}
+	}
+	return FormatESCPOS
+}
+
+// isParameterized reports whether b starts with PCL parameterized
+// command, like ESC & l 1 O. Combined commands have lowercase
+// parameter characters, like ESC & a 0 h 0 V.
+func isParameterized(b []byte) bool {
+	if len(b) < 4 || b[2] < '`' || b[2] > '~' {
+		return false
+	}
+	for _, c := range b[3:] {
+		if c >= '@' && c <= '^' {
+			return true
+		}
+		value := c >= '0' && c <= '9' || c == '.' || c == '+' || c == '-'
+		if !value && (c < '`' || c > '~') {
+			return false
+		}
+	}
+	return false
+}
+
+// isZPL reports whether b is ZPL label: it starts with ^XA command,
+// or with ~ commands followed by a label.
+func isZPL(b []byte) bool {
+	b = bytes.TrimLeft(b, " \t\r\n")
+	if bytes.HasPrefix(b, []byte("^XA")) {
+		return true
+	}
+	return len(b) >= 3 && b[0] == '~' && isUpper(b[1]) && isUpper(b[2]) && bytes.Contains(b, []byte("^XA"))
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+// eplSetup lists first letters of EPL2 commands that can precede
+// N command that clears image buffer.
+const eplSetup = "qQODSRIZU"
+
+// isEPL reports whether b is EPL2 label: its first line is N command,
+// or it starts with setup commands followed by N command.
+func isEPL(b []byte) bool {
+	lines := bytes.Split(b, []byte("\n"))
+	for i, l := range lines {
+		l = bytes.TrimRight(l, "\r")
+		switch {
+		case len(l) == 0:
+			continue
+		case len(l) == 1 && l[0] == 'N':
+			return true
+		case i == len(lines)-1:
+			// Last line can be incomplete.
+			return false
+		case bytes.IndexByte([]byte(eplSetup), l[0]) < 0 || len(l) < 2 || isLower(l[1]):
+			return false
+		}
+	}
+	return false
+}
+
+func isLower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+// isText reports whether b is plain UTF-8 text.
+func isText(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size == 1 {
+			// Data can end in the middle of UTF-8 sequence.
+			return len(b) < utf8.UTFMax && !utf8.FullRune(b)
+		}
+		if r < ' ' && r != '\t' && r != '\n' && r != '\r' && r != '\f' || r == 0x7f {
+			return false
+		}
+		b = b[size:]
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/bitmap"
+	"github.com/alexbrainman/printer/epl"
+	"github.com/alexbrainman/printer/escpos"
+	"github.com/alexbrainman/printer/pcl"
+	"github.com/alexbrainman/printer/pdf"
+	"github.com/alexbrainman/printer/raster"
+	"github.com/alexbrainman/printer/xps"
+	"github.com/alexbrainman/printer/zpl"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Format
+	}{
+		{"empty", "", FormatUnknown},
+		{"pdf", "%PDF-1.7\n%\xe2\xe3\xcf\xd3\n", FormatPDF},
+		{"postscript", "%!PS-Adobe-3.0\n%%Creator: test\n", FormatPostScript},
+		{"postscript ctrl-d", "\x04%!PS\n", FormatPostScript},
+		{"pcl", "\x1bE\x1b&l0O\x1b(s0p12h10v0s0b3T", FormatPCL},
+		{"pcl command", "\x1b&l1S\x1b*t300R", FormatPCL},
+		{"pcl symbol set", "\x1b(10U\x1b(s1P", FormatPCL},
+		{"pcl xl", ") HP-PCL XL;3;0;Comment\n", FormatPCLXL},
+		{"pjl pcl", uel + "@PJL JOB NAME=\"test\"\r\n@PJL ENTER LANGUAGE = PCL\r\n\x1bE", FormatPCL},
+		{"pjl postscript", uel + "@PJL SET COPIES=2\n@PJL ENTER LANGUAGE=POSTSCRIPT\n%!PS\n", FormatPostScript},
+		{"pjl pclxl", uel + "@PJL ENTER LANGUAGE=PCLXL\n) HP-PCL XL;2;0\n", FormatPCLXL},
+		{"pjl without language", uel + "@PJL JOB\n%PDF-1.4\n", FormatPDF},
+		{"pjl only", uel + "@PJL INFO STATUS\r\n" + uel, FormatPCL},
+		{"zpl", "^XA^FO50,50^ADN,36,20^FDHello^FS^XZ", FormatZPL},
+		{"zpl with spaces", "\r\n  ^XA\n^FO50,50^FDHello^FS\n^XZ\n", FormatZPL},
+		{"zpl download", "~DGR:LOGO.GRF,4,1,FF00FF00\n^XA^XGR:LOGO.GRF^FS^XZ", FormatZPL},
+		{"epl", "\nN\nA50,0,0,1,1,1,N,\"Hello\"\nP1\n", FormatEPL},
+		{"epl setup", "OD\r\nq812\r\nQ1218,24\r\nN\r\nP1\r\n", FormatEPL},
+		{"escpos", "\x1b@Hello\n\x1dV\x00", FormatESCPOS},
+		{"escpos bold", "\x1bE\x01Bold\x1bE\x00", FormatESCPOS},
+		{"escpos gs", "\x1d!\x11Big\n", FormatESCPOS},
+		{"pwg", "RaS2PwgRaster\x00", FormatPWGRaster},
+		{"urf", "UNIRAST\x00\x00\x00\x00\x01", FormatURF},
+		{"xps", "PK\x03\x04\x14\x00\x00\x00\x08\x00\x00\x00!\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1b\x00\x00\x00FixedDocumentSequence.fdseq", FormatXPS},
+		{"zip", "PK\x03\x04\x14\x00\x00\x00\x08\x00word/document.xml", FormatUnknown},
+		{"text", "Hello, world!\r\nSecond line\tdone\f", FormatText},
+		{"utf8 text", "Привет, мир\n", FormatText},
+		{"cut utf8 text", "Привет"[:5], FormatText},
+		{"text starting with N", "No labels here\n", FormatText},
+		{"binary", "\x00\x01\x02\x03", FormatUnknown},
+		{"short symbol set", "\x1b(", FormatESCPOS},
+		{"short secondary symbol set", "\x1b)", FormatESCPOS},
+		{"short font", "\x1b(s", FormatESCPOS},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat([]byte(tt.data)); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDetectGeneratedFormat(t *testing.T) {
+	m := image.NewGray(image.Rect(0, 0, 16, 16))
+	tests := []struct {
+		name string
+		gen  func(*bytes.Buffer) error
+		want Format
+	}{
+		{"pdf", func(b *bytes.Buffer) error {
+			w := pdf.NewWriter(b)
+			if _, err := w.NewPage(595, 842); err != nil {
+				return err
+			}
+			return w.Close()
+		}, FormatPDF},
+		{"pcl", func(b *bytes.Buffer) error {
+			return pcl.WriteBitmap(b, bitmap.New(16, 16), nil)
+		}, FormatPCL},
+		{"pwg", func(b *bytes.Buffer) error {
+			return raster.NewPWGWriter(b).WritePage(raster.NewPage(raster.Gray, 16, 16, 300))
+		}, FormatPWGRaster},
+		{"urf", func(b *bytes.Buffer) error {
+			return raster.NewURFWriter(b, 1).WritePage(raster.NewPage(raster.RGB, 16, 16, 300))
+		}, FormatURF},
+		{"escpos", func(b *bytes.Buffer) error {
+			e := escpos.New()
+			e.Text("Receipt\n")
+			_, err := e.WriteTo(b)
+			return err
+		}, FormatESCPOS},
+		{"zpl", func(b *bytes.Buffer) error {
+			z := zpl.New()
+			z.Start()
+			z.Text(10, 10, "Label")
+			z.End()
+			_, err := z.WriteTo(b)
+			return err
+		}, FormatZPL},
+		{"epl", func(b *bytes.Buffer) error {
+			e := epl.New()
+			e.Start()
+			e.Print(1)
+			_, err := e.WriteTo(b)
+			return err
+		}, FormatEPL},
+		{"xps", func(b *bytes.Buffer) error {
+			w := xps.NewWriter(b, nil)
+			if _, err := w.NewPage(816, 1056); err != nil {
+				return err
+			}
+			return w.Close()
+		}, FormatXPS},
+		{"png", func(b *bytes.Buffer) error {
+			return png.Encode(b, m)
+		}, FormatPNG},
+		{"jpeg", func(b *bytes.Buffer) error {
+			return jpeg.Encode(b, m, nil)
+		}, FormatJPEG},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := tt.gen(&b); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := DetectFormat(b.Bytes()); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDetectFormatLongText(t *testing.T) {
+	// Only the beginning of data is used, and it can end in the
+	// middle of UTF-8 sequence.
+	s := strings.Repeat("a", sniffLen-1) + "é" + "\x00"
+	if got := DetectFormat([]byte(s)); got != FormatText {
+		t.Errorf("got %v, want %v", got, FormatText)
+	}
+}
+
+func TestFormatMIMEType(t *testing.T) {
+	for f, want := range map[Format]string{
+		FormatPDF:       "application/pdf",
+		FormatPCL:       "application/vnd.hp-pcl",
+		FormatZPL:       "application/vnd.cups-raw",
+		FormatPWGRaster: "image/pwg-raster",
+		FormatUnknown:   "application/octet-stream",
+		Format(100):     "application/octet-stream",
+	} {
+		if got := f.MIMEType(); got != want {
+			t.Errorf("%v: got %q, want %q", f, got, want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"image"
+	"syscall"
+	"unsafe"
+
+	"github.com/alexbrainman/printer/media"
+	"golang.org/x/sys/windows"
+)
+
+type SIZEL struct {
+	Cx int32
+	Cy int32
+}
+
+type RECTL struct {
+	Left   int32
+	Top    int32
+	Right  int32
+	Bottom int32
+}
+
+type FORM_INFO_1 struct {
+	Flags         uint32
+	Name          *uint16
+	Size          SIZEL
+	ImageableArea RECTL
+}
+
+const (
+	FORM_USER    = 0x00000000
+	FORM_BUILTIN = 0x00000001
+	FORM_PRINTER = 0x00000002
+)
+
+//sys	EnumForms(h syscall.Handle, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) = winspool.EnumFormsW
+
+// FormInfo describes a form (paper size) known to the printer.
+// All dimensions are in thousandths of a millimeter.
+type FormInfo struct {
+	Name          string
+	Flags         uint32 // FORM_USER, FORM_BUILTIN or FORM_PRINTER
+	Width         int
+	Height        int
+	ImageableArea image.Rectangle
+}
+
+// Media returns PWG media size matching form f within 1mm.
+func (f *FormInfo) Media() (media.Size, bool) {
+	return media.LookupSize(f.Width/10, f.Height/10, 100)
+}
+
+// Forms returns forms supported by printer p.
+func (p *Printer) Forms() ([]FormInfo, error) {
+	var needed, returned uint32
+	buf := make([]byte, 1)
+	for {
+		err := EnumForms(p.h, 1, &buf[0], uint32(len(buf)), &needed, &returned)
+		if err == nil {
+			break
+		}
+		if err != syscall.ERROR_INSUFFICIENT_BUFFER {
+			return nil, err
+		}
+		if needed <= uint32(len(buf)) {
+			return nil, err
+		}
+		buf = make([]byte, needed)
+	}
+	if returned == 0 {
+		return nil, nil
+	}
+	fi := (*[1 << 20]FORM_INFO_1)(unsafe.Pointer(&buf[0]))[:returned:returned]
+	forms := make([]FormInfo, 0, returned)
+	for _, f := range fi {
+		forms = append(forms, FormInfo{
+			Name:   windows.UTF16PtrToString(f.Name),
+			Flags:  f.Flags,
+			Width:  int(f.Size.Cx),
+			Height: int(f.Size.Cy),
+			ImageableArea: image.Rect(
+				int(f.ImageableArea.Left),
+				int(f.ImageableArea.Top),
+				int(f.ImageableArea.Right),
+				int(f.ImageableArea.Bottom),
+			),
+		})
+	}
+	return forms, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+// Printer states (printer-state attribute).
+const (
+	PrinterIdle       = 3
+	PrinterProcessing = 4
+	PrinterStopped    = 5
+)
+
+// PrinterConfig describes CUPS printer queue configuration.
+type PrinterConfig struct {
+	Name      string
+	DeviceURI string // like "socket://192.168.1.10:9100" or "usb://HP/LaserJet"
+	PPDName   string // driver, like "everywhere" or "drv:///sample.drv/generic.ppd"; not changed, if empty
+	Info      string // description shown to users
+	Location  string
+	Shared    bool
+}
+
+// AddPrinter creates printer queue described by cfg, or changes it, if
+// queue cfg.Name exists already (CUPS-Add-Modify-Printer operation).
+// The queue is enabled and accepts jobs. Empty DeviceURI and PPDName
+// are not changed.
+func (c *Client) AddPrinter(cfg *PrinterConfig) error {
+	req := c.newRequest(OpCUPSAddModifyPrinter, c.PrinterURI(cfg.Name))
+	g := req.AddGroup(TagPrinter)
+	if cfg.DeviceURI != "" {
+		g.Add("device-uri", String(TagURI, cfg.DeviceURI))
+	}
+	if cfg.PPDName != "" {
+		g.Add("ppd-name", String(TagName, cfg.PPDName))
+	}
+	g.Add("printer-info", String(TagText, cfg.Info))
+	g.Add("printer-location", String(TagText, cfg.Location))
+	g.Add("printer-is-shared", Boolean(cfg.Shared))
+	g.Add("printer-is-accepting-jobs", Boolean(true))
+	g.Add("printer-state", Enum(PrinterIdle))
+	_, err := c.Do("/admin/", req)
+	return err
+}
+
+// DeletePrinter deletes printer queue name together with its jobs
+// (CUPS-Delete-Printer operation).
+func (c *Client) DeletePrinter(name string) error {
+	_, err := c.Do("/admin/", c.newRequest(OpCUPSDeletePrinter, c.PrinterURI(name)))
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAdmin(t *testing.T) {
+	printers := make(map[string]*Group)
+	c, reqs := testServer(t, func(req *Message) *Message {
+		uri := req.Group(TagOperation).String("printer-uri")
+		name := uri[strings.LastIndex(uri, "/")+1:]
+		switch req.Code {
+		case OpCUPSAddModifyPrinter:
+			printers[name] = req.Group(TagPrinter)
+		case OpCUPSDeletePrinter:
+			if printers[name] == nil {
+				return response(StatusNotFound)
+			}
+			delete(printers, name)
+		default:
+			return response(StatusNotSupported)
+		}
+		return response(StatusOK)
+	})
+	err := c.AddPrinter(&PrinterConfig{
+		Name:      "laser",
+		DeviceURI: "socket://192.168.1.10:9100",
+		PPDName:   "everywhere",
+		Info:      "Office laser",
+		Location:  "2nd floor",
+		Shared:    true,
+	})
+	if err != nil {
+		t.Fatalf("AddPrinter failed: %v", err)
+	}
+	want := &Group{Tag: TagPrinter, Attrs: []Attribute{
+		{"device-uri", []Value{String(TagURI, "socket://192.168.1.10:9100")}},
+		{"ppd-name", []Value{String(TagName, "everywhere")}},
+		{"printer-info", []Value{String(TagText, "Office laser")}},
+		{"printer-location", []Value{String(TagText, "2nd floor")}},
+		{"printer-is-shared", []Value{Boolean(true)}},
+		{"printer-is-accepting-jobs", []Value{Boolean(true)}},
+		{"printer-state", []Value{Enum(PrinterIdle)}},
+	}}
+	if got := printers["laser"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("printer attributes are\n%+v\nwant\n%+v", got, want)
+	}
+	if err := c.AddPrinter(&PrinterConfig{Name: "laser", Location: "3rd floor"}); err != nil {
+		t.Fatalf("AddPrinter failed: %v", err)
+	}
+	if g := printers["laser"]; g.Get("device-uri") != nil || g.Get("ppd-name") != nil {
+		t.Errorf("empty device URI and PPD name are sent: %+v", g)
+	}
+	if err := c.DeletePrinter("laser"); err != nil {
+		t.Fatalf("DeletePrinter failed: %v", err)
+	}
+	if len(printers) != 0 {
+		t.Errorf("printer is not deleted")
+	}
+	if err := c.DeletePrinter("laser"); !isStatus(err, StatusNotFound) {
+		t.Errorf("deleting missing printer returned %v", err)
+	}
+	for _, r := range *reqs {
+		if r.path != "/admin/" {
+			t.Errorf("request 0x%04x is sent to %q, want /admin/", r.msg.Code, r.path)
+		}
+	}
+}
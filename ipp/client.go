@@ -0,0 +1,121 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Client sends requests to IPP server.
+type Client struct {
+	// URL is server address, like "http://localhost:631".
+	URL string
+	// UserName is sent as requesting-user-name attribute, if set.
+	UserName string
+	// HTTPClient sends requests. http.DefaultClient is used, if nil.
+	HTTPClient *http.Client
+
+	requestID uint32
+}
+
+// NewClient returns client of CUPS server, like "server", "server:631"
+// or "/run/cups/cups.sock". Port 631 is used, if server has no port.
+func NewClient(server string) *Client {
+	c := &Client{UserName: os.Getenv("USER")}
+	if strings.HasPrefix(server, "/") {
+		// Domain socket.
+		var d net.Dialer
+		c.URL = "http://localhost"
+		c.HTTPClient = &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return d.DialContext(ctx, "unix", server)
+			},
+		}}
+		return c
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "631")
+	}
+	c.URL = "http://" + server
+	return c
+}
+
+// NewCUPSClient returns client of CUPS server set by CUPS_SERVER
+// environment variable (see NewClient), or of local CUPS server,
+// if the variable is not set.
+func NewCUPSClient() *Client {
+	server := os.Getenv("CUPS_SERVER")
+	if server == "" {
+		server = "localhost"
+	}
+	return NewClient(server)
+}
+
+// PrinterURI returns URI of CUPS printer queue name on server c.
+func (c *Client) PrinterURI(name string) string {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return ""
+	}
+	u.Scheme = "ipp"
+	u.Path = "/printers/" + name
+	return u.String()
+}
+
+// newRequest returns request of operation op for printer with URI
+// printerURI. Empty printerURI is not sent.
+func (c *Client) newRequest(op uint16, printerURI string) *Message {
+	m := NewRequest(op)
+	g := m.Groups[0]
+	if printerURI != "" {
+		g.Add("printer-uri", String(TagURI, printerURI))
+	}
+	if c.UserName != "" {
+		g.Add("requesting-user-name", String(TagName, c.UserName))
+	}
+	return m
+}
+
+// Do sends request req to resource path of server c, like "/" or
+// "/admin/", and returns server response. It returns *Error, if
+// server does not complete the request successfully.
+func (c *Client) Do(path string, req *Message) (*Message, error) {
+	req.RequestID = atomic.AddUint32(&c.requestID, 1)
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Post(strings.TrimSuffix(c.URL, "/")+path, "application/ipp", bytes.NewReader(req.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ipp: %s", resp.Status)
+	}
+	m, err := Decode(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Successful status codes are below 0x0100.
+	if m.Code >= 0x0100 {
+		return nil, &Error{Status: m.Code, Message: m.Group(TagOperation).String("status-message")}
+	}
+	return m, nil
+}
+
+// isStatus reports, if err is *Error with status.
+func isStatus(err error, status uint16) bool {
+	e, ok := err.(*Error)
+	return ok && e.Status == status
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// request is IPP request received by test server.
+type request struct {
+	path string
+	msg  *Message
+}
+
+// testServer starts stand-in IPP server that answers requests with
+// handle, and returns client of the server and requests it received.
+func testServer(t *testing.T, handle func(req *Message) *Message) (*Client, *[]request) {
+	var reqs []request
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/ipp" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		req, err := Decode(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reqs = append(reqs, request{r.URL.Path, req})
+		resp := handle(req)
+		resp.Version = req.Version
+		resp.RequestID = req.RequestID
+		w.Header().Set("Content-Type", "application/ipp")
+		w.Write(resp.Encode())
+	}))
+	t.Cleanup(s.Close)
+	return &Client{URL: s.URL, UserName: "alex"}, &reqs
+}
+
+// response returns response with status and operation attributes.
+func response(status uint16) *Message {
+	m := NewRequest(0)
+	m.Code = status
+	return m
+}
+
+func TestDo(t *testing.T) {
+	c, reqs := testServer(t, func(req *Message) *Message {
+		resp := response(StatusNotAuthorized)
+		resp.Groups[0].Add("status-message", String(TagText, "not allowed"))
+		return resp
+	})
+	_, err := c.Do("/admin/", c.newRequest(OpCUPSDeletePrinter, c.PrinterURI("laser")))
+	want := &Error{Status: StatusNotAuthorized, Message: "not allowed"}
+	if e, ok := err.(*Error); !ok || *e != *want {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+	r := (*reqs)[0]
+	if r.path != "/admin/" || r.msg.Code != OpCUPSDeletePrinter || r.msg.RequestID != 1 {
+		t.Errorf("unexpected request %s %+v", r.path, r.msg)
+	}
+	g := r.msg.Group(TagOperation)
+	if got := g.String("printer-uri"); got != "ipp://"+c.URL[len("http://"):]+"/printers/laser" {
+		t.Errorf("printer-uri is %q", got)
+	}
+	if got := g.String("requesting-user-name"); got != "alex" {
+		t.Errorf("requesting-user-name is %q, want %q", got, "alex")
+	}
+	if got := g.Attrs[0].Name; got != "attributes-charset" {
+		t.Errorf("the first operation attribute is %q, want attributes-charset", got)
+	}
+}
+
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestNewCUPSClient(t *testing.T) {
+	for _, test := range []struct {
+		server, url string
+	}{
+		{"", "http://localhost:631"},
+		{"print.example.com", "http://print.example.com:631"},
+		{"print.example.com:8631", "http://print.example.com:8631"},
+		{"/run/cups/cups.sock", "http://localhost"},
+	} {
+		setenv(t, "CUPS_SERVER", test.server)
+		if got := NewCUPSClient().URL; got != test.url {
+			t.Errorf("CUPS_SERVER=%q: URL is %q, want %q", test.server, got, test.url)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	name := "laser"
+	c, reqs := testServer(t, func(req *Message) *Message {
+		if name == "" {
+			return response(StatusNotFound)
+		}
+		resp := response(StatusOK)
+		resp.AddGroup(TagPrinter).Add("printer-name", String(TagName, name))
+		return resp
+	})
+	got, err := c.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "laser" {
+		t.Errorf("Default returned %q, want %q", got, "laser")
+	}
+	if r := (*reqs)[0]; r.msg.Code != OpCUPSGetDefault || r.msg.Group(TagOperation).String("requested-attributes") != "printer-name" {
+		t.Errorf("unexpected request %+v", r.msg)
+	}
+	name = ""
+	if _, err := c.Default(); err != ErrNoDefault {
+		t.Errorf("Default returned %v, want %v", err, ErrNoDefault)
+	}
+}
+
+func TestPrinterNames(t *testing.T) {
+	var names []string
+	c, _ := testServer(t, func(req *Message) *Message {
+		if len(names) == 0 {
+			return response(StatusNotFound)
+		}
+		resp := response(StatusOK)
+		for _, name := range names {
+			g := resp.AddGroup(TagPrinter)
+			g.Add("printer-name", String(TagName, name))
+			g.Add("printer-state", Enum(PrinterIdle))
+		}
+		return resp
+	})
+	got, err := c.PrinterNames()
+	if err != nil || len(got) != 0 {
+		t.Fatalf("PrinterNames returned %q, %v, want no printers", got, err)
+	}
+	names = []string{"laser", "label"}
+	got, err = c.PrinterNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, names) {
+		t.Errorf("PrinterNames returned %q, want %q", got, names)
+	}
+}
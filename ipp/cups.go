@@ -0,0 +1,50 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import "errors"
+
+var ErrNoDefault = errors.New("ipp: server has no default printer")
+
+// Default returns name of server c default printer
+// (CUPS-Get-Default operation).
+func (c *Client) Default() (string, error) {
+	req := c.newRequest(OpCUPSGetDefault, "")
+	req.Groups[0].Add("requested-attributes", String(TagKeyword, "printer-name"))
+	resp, err := c.Do("/", req)
+	if isStatus(err, StatusNotFound) {
+		return "", ErrNoDefault
+	}
+	if err != nil {
+		return "", err
+	}
+	name := resp.Group(TagPrinter).String("printer-name")
+	if name == "" {
+		return "", ErrNoDefault
+	}
+	return name, nil
+}
+
+// PrinterNames returns names of all printer queues on server c
+// (CUPS-Get-Printers operation).
+func (c *Client) PrinterNames() ([]string, error) {
+	req := c.newRequest(OpCUPSGetPrinters, "")
+	req.Groups[0].Add("requested-attributes", String(TagKeyword, "printer-name"))
+	resp, err := c.Do("/", req)
+	if isStatus(err, StatusNotFound) {
+		// Server has no printers.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, g := range resp.All(TagPrinter) {
+		if name := g.String("printer-name"); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,403 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ipp implements Internet Printing Protocol (RFC 8010 and
+// RFC 8011) client with CUPS extensions. It is used by the printer
+// package on systems other than Windows, where printers are managed
+// by CUPS.
+//
+// Only attributes used by this package have helpers. Other attributes
+// can be read from response Message groups directly.
+package ipp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var ErrMessage = errors.New("ipp: malformed message")
+
+// Operation codes.
+const (
+	OpGetJobAttributes           = 0x0009
+	OpGetPrinterAttributes       = 0x000b
+	OpCreatePrinterSubscriptions = 0x0016
+	OpCancelSubscription         = 0x001b
+	OpGetNotifications           = 0x001c
+	OpCUPSGetDefault             = 0x4001
+	OpCUPSGetPrinters            = 0x4002
+	OpCUPSAddModifyPrinter       = 0x4003
+	OpCUPSDeletePrinter          = 0x4004
+)
+
+// Status codes.
+const (
+	StatusOK               = 0x0000
+	StatusBadRequest       = 0x0400
+	StatusForbidden        = 0x0401
+	StatusNotAuthenticated = 0x0402
+	StatusNotAuthorized    = 0x0403
+	StatusNotPossible      = 0x0404
+	StatusNotFound         = 0x0406
+	StatusInternalError    = 0x0500
+	StatusNotSupported     = 0x0501
+)
+
+// Delimiter tags that start attribute groups.
+const (
+	TagOperation    = 0x01
+	TagJob          = 0x02
+	tagEnd          = 0x03
+	TagPrinter      = 0x04
+	TagUnsupported  = 0x05
+	TagSubscription = 0x06
+	TagEvent        = 0x07
+)
+
+// Value tags.
+const (
+	TagNoValue          = 0x13
+	TagInteger          = 0x21
+	TagBoolean          = 0x22
+	TagEnum             = 0x23
+	TagOctetString      = 0x30
+	TagDateTime         = 0x31
+	TagResolution       = 0x32
+	TagRange            = 0x33
+	TagBeginCollection  = 0x34
+	TagTextWithLanguage = 0x35
+	TagNameWithLanguage = 0x36
+	TagEndCollection    = 0x37
+	TagText             = 0x41
+	TagName             = 0x42
+	TagKeyword          = 0x44
+	TagURI              = 0x45
+	TagURIScheme        = 0x46
+	TagCharset          = 0x47
+	TagLanguage         = 0x48
+	TagMIMEType         = 0x49
+	TagMemberName       = 0x4a
+)
+
+// Value is attribute value. Integer, enum and boolean values are
+// stored in Int, and character string values in String. Other
+// values, like dateTime or resolution, are kept in Bytes as encoded.
+// Text and names with language are decoded as text and names without
+// language.
+type Value struct {
+	Tag    byte
+	Int    int
+	String string
+	Bytes  []byte
+}
+
+// Integer returns value of integer n.
+func Integer(n int) Value { return Value{Tag: TagInteger, Int: n} }
+
+// Enum returns value of enum n.
+func Enum(n int) Value { return Value{Tag: TagEnum, Int: n} }
+
+// Boolean returns value of boolean b.
+func Boolean(b bool) Value {
+	v := Value{Tag: TagBoolean}
+	if b {
+		v.Int = 1
+	}
+	return v
+}
+
+// String returns value of character string s with tag, like TagKeyword
+// or TagURI.
+func String(tag byte, s string) Value { return Value{Tag: tag, String: s} }
+
+// Time returns time stored in dateTime value v, or zero time, if
+// v is not dateTime.
+func (v Value) Time() time.Time {
+	b := v.Bytes
+	if v.Tag != TagDateTime || len(b) != 11 {
+		return time.Time{}
+	}
+	offset := (int(b[9])*60 + int(b[10])) * 60
+	if b[8] == '-' {
+		offset = -offset
+	}
+	return time.Date(int(binary.BigEndian.Uint16(b)), time.Month(b[2]), int(b[3]),
+		int(b[4]), int(b[5]), int(b[6]), int(b[7])*100000000, time.FixedZone("", offset))
+}
+
+// Attribute is named attribute with one or more values. Collection
+// values are stored as encoded: begin collection value is followed
+// by member name and member values, and end collection value.
+type Attribute struct {
+	Name   string
+	Values []Value
+}
+
+// Group is attribute group, like operation or printer attributes.
+type Group struct {
+	Tag   byte
+	Attrs []Attribute
+}
+
+// Add appends attribute name with values to g.
+func (g *Group) Add(name string, values ...Value) {
+	g.Attrs = append(g.Attrs, Attribute{Name: name, Values: values})
+}
+
+// Get returns attribute name of g, or nil, if g has no such attribute.
+func (g *Group) Get(name string) *Attribute {
+	if g == nil {
+		return nil
+	}
+	for i := range g.Attrs {
+		if g.Attrs[i].Name == name {
+			return &g.Attrs[i]
+		}
+	}
+	return nil
+}
+
+// String returns the first value of string attribute name of g,
+// or empty string.
+func (g *Group) String(name string) string {
+	if a := g.Get(name); a != nil && len(a.Values) > 0 {
+		return a.Values[0].String
+	}
+	return ""
+}
+
+// Int returns the first value of integer, enum or boolean attribute
+// name of g, or 0.
+func (g *Group) Int(name string) int {
+	if a := g.Get(name); a != nil && len(a.Values) > 0 {
+		return a.Values[0].Int
+	}
+	return 0
+}
+
+// Strings returns all values of string attribute name of g.
+func (g *Group) Strings(name string) []string {
+	a := g.Get(name)
+	if a == nil {
+		return nil
+	}
+	s := make([]string, len(a.Values))
+	for i, v := range a.Values {
+		s[i] = v.String
+	}
+	return s
+}
+
+// Message is IPP request or response.
+type Message struct {
+	Version   uint16 // major and minor version, like 0x0101 for IPP/1.1
+	Code      uint16 // operation in requests, status in responses
+	RequestID uint32
+	Groups    []*Group
+}
+
+// NewRequest returns IPP/1.1 request of operation op with operation
+// attributes group that has charset and natural language attributes.
+func NewRequest(op uint16) *Message {
+	m := &Message{Version: 0x0101, Code: op}
+	g := m.AddGroup(TagOperation)
+	g.Add("attributes-charset", String(TagCharset, "utf-8"))
+	g.Add("attributes-natural-language", String(TagLanguage, "en"))
+	return m
+}
+
+// AddGroup appends new attribute group tag to m and returns it.
+func (m *Message) AddGroup(tag byte) *Group {
+	g := &Group{Tag: tag}
+	m.Groups = append(m.Groups, g)
+	return g
+}
+
+// Group returns the first attribute group tag of m, or nil.
+func (m *Message) Group(tag byte) *Group {
+	for _, g := range m.Groups {
+		if g.Tag == tag {
+			return g
+		}
+	}
+	return nil
+}
+
+// All returns all attribute groups tag of m, like printers returned
+// by CUPS-Get-Printers.
+func (m *Message) All(tag byte) []*Group {
+	var gs []*Group
+	for _, g := range m.Groups {
+		if g.Tag == tag {
+			gs = append(gs, g)
+		}
+	}
+	return gs
+}
+
+// encodeValue returns v as encoded in IPP message.
+func encodeValue(v Value) []byte {
+	switch {
+	case v.Tag == TagInteger || v.Tag == TagEnum:
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, uint32(int32(v.Int)))
+		return b
+	case v.Tag == TagBoolean:
+		if v.Int != 0 {
+			return []byte{1}
+		}
+		return []byte{0}
+	case v.Tag >= 0x40 && v.Tag <= 0x5f:
+		return []byte(v.String)
+	}
+	return v.Bytes
+}
+
+// Encode returns m encoded as IPP message.
+func (m *Message) Encode() []byte {
+	b := make([]byte, 8, 256)
+	binary.BigEndian.PutUint16(b, m.Version)
+	binary.BigEndian.PutUint16(b[2:], m.Code)
+	binary.BigEndian.PutUint32(b[4:], m.RequestID)
+	put := func(tag byte, name string, value []byte) {
+		b = append(b, tag, byte(len(name)>>8), byte(len(name)))
+		b = append(b, name...)
+		b = append(b, byte(len(value)>>8), byte(len(value)))
+		b = append(b, value...)
+	}
+	for _, g := range m.Groups {
+		b = append(b, g.Tag)
+		for _, a := range g.Attrs {
+			for i, v := range a.Values {
+				name := a.Name
+				if i > 0 {
+					name = ""
+				}
+				put(v.Tag, name, encodeValue(v))
+			}
+		}
+	}
+	return append(b, tagEnd)
+}
+
+// decodeValue returns value of tag encoded as b.
+func decodeValue(tag byte, b []byte) (Value, error) {
+	v := Value{Tag: tag}
+	switch {
+	case tag == TagInteger || tag == TagEnum:
+		if len(b) != 4 {
+			return v, ErrMessage
+		}
+		v.Int = int(int32(binary.BigEndian.Uint32(b)))
+	case tag == TagBoolean:
+		if len(b) != 1 {
+			return v, ErrMessage
+		}
+		v.Int = int(b[0])
+	case tag == TagTextWithLanguage || tag == TagNameWithLanguage:
+		// Language is dropped.
+		v.Tag = TagText
+		if tag == TagNameWithLanguage {
+			v.Tag = TagName
+		}
+		if len(b) < 2 {
+			return v, ErrMessage
+		}
+		n := int(binary.BigEndian.Uint16(b))
+		if len(b) < 4+n {
+			return v, ErrMessage
+		}
+		b = b[2+n:]
+		n = int(binary.BigEndian.Uint16(b))
+		if len(b) != 2+n {
+			return v, ErrMessage
+		}
+		v.String = string(b[2:])
+	case tag >= 0x40 && tag <= 0x5f:
+		v.String = string(b)
+	default:
+		v.Bytes = b
+	}
+	return v, nil
+}
+
+// Decode reads IPP message from r. Document data that follows
+// message attributes is not read.
+func Decode(r io.Reader) (*Message, error) {
+	var hdr [8]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, ErrMessage
+	}
+	m := &Message{
+		Version:   binary.BigEndian.Uint16(hdr[:]),
+		Code:      binary.BigEndian.Uint16(hdr[2:]),
+		RequestID: binary.BigEndian.Uint32(hdr[4:]),
+	}
+	read := func() ([]byte, error) {
+		var n [2]byte
+		if _, err := io.ReadFull(r, n[:]); err != nil {
+			return nil, ErrMessage
+		}
+		b := make([]byte, binary.BigEndian.Uint16(n[:]))
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, ErrMessage
+		}
+		return b, nil
+	}
+	var g *Group
+	for {
+		var tag [1]byte
+		if _, err := io.ReadFull(r, tag[:]); err != nil {
+			return nil, ErrMessage
+		}
+		if tag[0] == tagEnd {
+			return m, nil
+		}
+		if tag[0] < 0x10 {
+			g = m.AddGroup(tag[0])
+			continue
+		}
+		if g == nil {
+			return nil, ErrMessage
+		}
+		name, err := read()
+		if err != nil {
+			return nil, err
+		}
+		b, err := read()
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeValue(tag[0], b)
+		if err != nil {
+			return nil, err
+		}
+		if len(name) == 0 {
+			// Additional value of the previous attribute.
+			if len(g.Attrs) == 0 {
+				return nil, ErrMessage
+			}
+			a := &g.Attrs[len(g.Attrs)-1]
+			a.Values = append(a.Values, v)
+			continue
+		}
+		g.Add(string(name), v)
+	}
+}
+
+// Error is error status returned by IPP server.
+type Error struct {
+	Status  uint16
+	Message string // status-message attribute, if server sent it
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("ipp: %s (status 0x%04x)", e.Message, e.Status)
+	}
+	return fmt.Sprintf("ipp: request failed with status 0x%04x", e.Status)
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// getPrintersResponse is CUPS-Get-Printers response with two printers.
+var getPrintersResponse = []byte("\x01\x01\x00\x00\x00\x00\x00\x07" +
+	"\x01" +
+	"\x47\x00\x12attributes-charset\x00\x05utf-8" +
+	"\x48\x00\x1battributes-natural-language\x00\x02en" +
+	"\x04" +
+	"\x42\x00\x0cprinter-name\x00\x05laser" +
+	"\x44\x00\x15printer-state-reasons\x00\x04none" +
+	"\x44\x00\x00\x00\x10toner-low-report" +
+	"\x22\x00\x11printer-is-shared\x00\x01\x01" +
+	"\x04" +
+	"\x36\x00\x0cprinter-name\x00\x0b\x00\x02en\x00\x05label" +
+	"\x23\x00\x0dprinter-state\x00\x04\x00\x00\x00\x05" +
+	"\x03" +
+	"document data")
+
+func TestDecode(t *testing.T) {
+	m, err := Decode(bytes.NewReader(getPrintersResponse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != 0x0101 || m.Code != StatusOK || m.RequestID != 7 {
+		t.Errorf("unexpected message header %+v", m)
+	}
+	printers := m.All(TagPrinter)
+	if len(printers) != 2 {
+		t.Fatalf("got %d printer groups, want 2", len(printers))
+	}
+	want := &Group{Tag: TagPrinter, Attrs: []Attribute{
+		{"printer-name", []Value{String(TagName, "laser")}},
+		{"printer-state-reasons", []Value{String(TagKeyword, "none"), String(TagKeyword, "toner-low-report")}},
+		{"printer-is-shared", []Value{Boolean(true)}},
+	}}
+	if !reflect.DeepEqual(printers[0], want) {
+		t.Errorf("got %+v\nwant %+v", printers[0], want)
+	}
+	if got := printers[1].String("printer-name"); got != "label" {
+		t.Errorf("name with language is %q, want %q", got, "label")
+	}
+	if got := printers[1].Int("printer-state"); got != 5 {
+		t.Errorf("printer-state is %d, want 5", got)
+	}
+	if got := m.Group(TagOperation).String("attributes-charset"); got != "utf-8" {
+		t.Errorf("charset is %q, want %q", got, "utf-8")
+	}
+
+	if v := printers[1].Attrs[0].Values[0]; v.Tag != TagName {
+		t.Errorf("name with language is decoded with tag 0x%02x", v.Tag)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	m := NewRequest(OpCUPSAddModifyPrinter)
+	m.RequestID = 3
+	m.Groups[0].Add("printer-uri", String(TagURI, "ipp://localhost/printers/laser"))
+	g := m.AddGroup(TagPrinter)
+	g.Add("printer-state", Enum(3))
+	g.Add("job-priority", Integer(-1))
+	g.Add("printer-is-shared", Boolean(false))
+	g.Add("notify-events", String(TagKeyword, "job-created"), String(TagKeyword, "job-completed"))
+	g.Add("printer-resolution", Value{Tag: TagResolution, Bytes: []byte{0, 0, 2, 0x58, 0, 0, 2, 0x58, 3}})
+	b := m.Encode()
+	want := "\x01\x01\x40\x03\x00\x00\x00\x03" +
+		"\x01" +
+		"\x47\x00\x12attributes-charset\x00\x05utf-8" +
+		"\x48\x00\x1battributes-natural-language\x00\x02en" +
+		"\x45\x00\x0bprinter-uri\x00\x1eipp://localhost/printers/laser" +
+		"\x04" +
+		"\x23\x00\x0dprinter-state\x00\x04\x00\x00\x00\x03" +
+		"\x21\x00\x0cjob-priority\x00\x04\xff\xff\xff\xff" +
+		"\x22\x00\x11printer-is-shared\x00\x01\x00" +
+		"\x44\x00\x0dnotify-events\x00\x0bjob-created" +
+		"\x44\x00\x00\x00\x0djob-completed" +
+		"\x32\x00\x12printer-resolution\x00\x09\x00\x00\x02\x58\x00\x00\x02\x58\x03" +
+		"\x03"
+	if string(b) != want {
+		t.Fatalf("Encode returned\n%q\nwant\n%q", b, want)
+	}
+	m2, err := Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m2, m) {
+		t.Errorf("message does not round trip:\n%+v\nwant\n%+v", m2, m)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for i, b := range [][]byte{
+		nil,
+		[]byte("\x01\x01\x00\x00\x00\x00\x00\x01"),
+		[]byte("\x01\x01\x00\x00\x00\x00\x00\x01\x42\x00\x01a\x00\x01b\x03"),
+		[]byte("\x01\x01\x00\x00\x00\x00\x00\x01\x01\x42\x00\x00\x00\x01b\x03"),
+		[]byte("\x01\x01\x00\x00\x00\x00\x00\x01\x01\x21\x00\x01a\x00\x02\x00\x01\x03"),
+		[]byte("\x01\x01\x00\x00\x00\x00\x00\x01\x01\x42\x00\x05a\x00\x01b\x03"),
+	} {
+		if _, err := Decode(bytes.NewReader(b)); err != ErrMessage {
+			t.Errorf("message %d: got %v, want %v", i, err, ErrMessage)
+		}
+	}
+}
+
+func TestValueTime(t *testing.T) {
+	v := Value{Tag: TagDateTime, Bytes: []byte{0x07, 0xea, 10, 18, 14, 30, 5, 2, '-', 5, 30}}
+	want := time.Date(2026, 10, 18, 20, 0, 5, 200000000, time.UTC)
+	if got := v.Time(); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !Integer(1).Time().IsZero() {
+		t.Errorf("integer value has time")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import "time"
+
+// Job states (job-state attribute).
+const (
+	JobPending    = 3
+	JobHeld       = 4
+	JobProcessing = 5
+	JobStopped    = 6
+	JobCanceled   = 7
+	JobAborted    = 8
+	JobCompleted  = 9
+)
+
+// JobDetails stores print job attributes.
+type JobDetails struct {
+	JobID          int
+	Name           string // job-name
+	UserName       string // job-originating-user-name
+	PrinterURI     string // job-printer-uri
+	State          int    // one of Job* states
+	StateReasons   []string
+	StateMessage   string
+	Priority       int   // from 1 to 100
+	Size           int64 // job size in bytes, rounded up to kilobytes
+	DocumentFormat string
+	Impressions    int // total number of impressions, or 0, if unknown
+	Completed      int // number of impressions printed
+	Created        time.Time
+	Processing     time.Time // zero, if job did not start printing
+	Finished       time.Time // zero, if job is not finished
+}
+
+// jobTime returns time stored in date-time-at-name attribute of g,
+// or, if g does not have it, time in time-at-name attribute, that
+// CUPS stores in seconds since Unix epoch.
+func jobTime(g *Group, name string) time.Time {
+	if a := g.Get("date-time-at-" + name); a != nil && len(a.Values) > 0 {
+		if t := a.Values[0].Time(); !t.IsZero() {
+			return t
+		}
+	}
+	if n := g.Int("time-at-" + name); n > 0 {
+		return time.Unix(int64(n), 0)
+	}
+	return time.Time{}
+}
+
+// newJobDetails returns job described by job attributes group g.
+func newJobDetails(g *Group) *JobDetails {
+	return &JobDetails{
+		JobID:          g.Int("job-id"),
+		Name:           g.String("job-name"),
+		UserName:       g.String("job-originating-user-name"),
+		PrinterURI:     g.String("job-printer-uri"),
+		State:          g.Int("job-state"),
+		StateReasons:   g.Strings("job-state-reasons"),
+		StateMessage:   g.String("job-state-message"),
+		Priority:       g.Int("job-priority"),
+		Size:           int64(g.Int("job-k-octets")) * 1024,
+		DocumentFormat: g.String("document-format"),
+		Impressions:    g.Int("job-impressions"),
+		Completed:      g.Int("job-impressions-completed"),
+		Created:        jobTime(g, "creation"),
+		Processing:     jobTime(g, "processing"),
+		Finished:       jobTime(g, "completed"),
+	}
+}
+
+// JobDetails returns attributes of job id of printer queue name
+// (Get-Job-Attributes operation).
+func (c *Client) JobDetails(name string, id int) (*JobDetails, error) {
+	req := c.newRequest(OpGetJobAttributes, c.PrinterURI(name))
+	g := req.Groups[0]
+	g.Add("job-id", Integer(id))
+	g.Add("requested-attributes", String(TagKeyword, "all"))
+	resp, err := c.Do("/jobs/", req)
+	if err != nil {
+		return nil, err
+	}
+	jg := resp.Group(TagJob)
+	if jg == nil {
+		return nil, ErrMessage
+	}
+	return newJobDetails(jg), nil
+}
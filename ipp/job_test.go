@@ -0,0 +1,90 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// jobResponse is Get-Job-Attributes response sent by CUPS.
+var jobResponse = []byte("\x02\x00\x00\x00\x00\x00\x00\x01" +
+	"\x01" +
+	"\x47\x00\x12attributes-charset\x00\x05utf-8" +
+	"\x48\x00\x1battributes-natural-language\x00\x02en" +
+	"\x02" +
+	"\x21\x00\x06job-id\x00\x04\x00\x00\x00\x2a" +
+	"\x42\x00\x08job-name\x00\x06report" +
+	"\x42\x00\x19job-originating-user-name\x00\x04alex" +
+	"\x45\x00\x0fjob-printer-uri\x00\x1eipp://localhost/printers/laser" +
+	"\x23\x00\x09job-state\x00\x04\x00\x00\x00\x05" +
+	"\x44\x00\x11job-state-reasons\x00\x0cjob-printing" +
+	"\x44\x00\x00\x00\x18job-hold-until-specified" +
+	"\x41\x00\x11job-state-message\x00\x0dprinting page" +
+	"\x21\x00\x0cjob-priority\x00\x04\x00\x00\x00\x32" +
+	"\x21\x00\x0cjob-k-octets\x00\x04\x00\x00\x00\x0c" +
+	"\x49\x00\x0fdocument-format\x00\x0fapplication/pdf" +
+	"\x21\x00\x0fjob-impressions\x00\x04\x00\x00\x00\x04" +
+	"\x21\x00\x19job-impressions-completed\x00\x04\x00\x00\x00\x01" +
+	"\x31\x00\x15date-time-at-creation\x00\x0b\x07\xea\x0a\x12\x0c\x00\x00\x00+\x02\x00" +
+	"\x21\x00\x12time-at-processing\x00\x04\x6a\x3d\x1c\x00" +
+	"\x13\x00\x11time-at-completed\x00\x00" +
+	"\x03")
+
+func TestJobDetails(t *testing.T) {
+	m, err := Decode(bytes.NewReader(jobResponse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := newJobDetails(m.Group(TagJob))
+	want := &JobDetails{
+		JobID:          42,
+		Name:           "report",
+		UserName:       "alex",
+		PrinterURI:     "ipp://localhost/printers/laser",
+		State:          JobProcessing,
+		StateReasons:   []string{"job-printing", "job-hold-until-specified"},
+		StateMessage:   "printing page",
+		Priority:       50,
+		Size:           12 * 1024,
+		DocumentFormat: "application/pdf",
+		Impressions:    4,
+		Completed:      1,
+		Created:        time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		Processing:     time.Unix(0x6a3d1c00, 0),
+	}
+	if !got.Created.Equal(want.Created) {
+		t.Errorf("Created is %v, want %v", got.Created, want.Created)
+	}
+	got.Created = want.Created
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestClientJobDetails(t *testing.T) {
+	var req *Message
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ = Decode(r.Body)
+		w.Write(jobResponse)
+	}))
+	defer s.Close()
+	c := &Client{URL: s.URL}
+	j, err := c.JobDetails("laser", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j.JobID != 42 || j.State != JobProcessing {
+		t.Errorf("unexpected job %+v", j)
+	}
+	g := req.Group(TagOperation)
+	if req.Code != OpGetJobAttributes || g.Int("job-id") != 42 || g.String("printer-uri") != c.PrinterURI("laser") {
+		t.Errorf("unexpected request %+v", req)
+	}
+}
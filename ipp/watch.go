@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import (
+	"context"
+	"time"
+)
+
+// Event is IPP event notification.
+type Event struct {
+	Name         string // notify-subscribed-event, like "job-state-changed"
+	Sequence     int    // notify-sequence-number
+	JobID        int    // 0 for printer events
+	JobState     int    // one of Job* states for job events
+	PrinterState int    // one of Printer* states
+	Text         string // notify-text
+	Attrs        *Group // all notification attributes
+	Err          error  // set on the last event, if watching failed
+}
+
+// defaultEvents are events reported by Watch, if none are requested.
+var defaultEvents = []string{"job-created", "job-state-changed", "job-completed", "printer-state-changed"}
+
+// pollInterval is how often Watch asks server for new events, if
+// server does not tell (notify-get-interval attribute).
+var pollInterval = 5 * time.Second
+
+// subscribe creates pull subscription to events of printer with URI
+// printerURI, and returns subscription id.
+func (c *Client) subscribe(printerURI string, events []string) (int, error) {
+	req := c.newRequest(OpCreatePrinterSubscriptions, printerURI)
+	g := req.AddGroup(TagSubscription)
+	g.Add("notify-pull-method", String(TagKeyword, "ippget"))
+	var vs []Value
+	for _, e := range events {
+		vs = append(vs, String(TagKeyword, e))
+	}
+	g.Add("notify-events", vs...)
+	resp, err := c.Do("/", req)
+	if err != nil {
+		return 0, err
+	}
+	id := resp.Group(TagSubscription).Int("notify-subscription-id")
+	if id == 0 {
+		return 0, ErrMessage
+	}
+	return id, nil
+}
+
+// notifications returns events of subscription id starting from
+// sequence number seq, and how long to wait before asking again.
+func (c *Client) notifications(printerURI string, id, seq int) ([]Event, time.Duration, error) {
+	req := c.newRequest(OpGetNotifications, printerURI)
+	g := req.Groups[0]
+	g.Add("notify-subscription-ids", Integer(id))
+	g.Add("notify-sequence-numbers", Integer(seq))
+	g.Add("notify-wait", Boolean(false))
+	resp, err := c.Do("/", req)
+	if err != nil {
+		return nil, 0, err
+	}
+	wait := pollInterval
+	if n := resp.Group(TagOperation).Int("notify-get-interval"); n > 0 {
+		wait = time.Duration(n) * time.Second
+	}
+	var events []Event
+	for _, g := range resp.All(TagEvent) {
+		events = append(events, Event{
+			Name:         g.String("notify-subscribed-event"),
+			Sequence:     g.Int("notify-sequence-number"),
+			JobID:        g.Int("notify-job-id"),
+			JobState:     g.Int("job-state"),
+			PrinterState: g.Int("printer-state"),
+			Text:         g.String("notify-text"),
+			Attrs:        g,
+		})
+	}
+	return events, wait, nil
+}
+
+// Watch reports events of printer queue name. events are notify-events
+// keywords, like "job-state-changed" or "printer-config-changed". Nil
+// events means job creation, completion and state changes, and printer
+// state changes. Watch subscribes to the events with
+// Create-Printer-Subscriptions operation, and asks server for new
+// events with Get-Notifications. Returned channel is closed, and the
+// subscription is canceled, when ctx is done or when watching fails.
+func (c *Client) Watch(ctx context.Context, name string, events []string) (<-chan Event, error) {
+	if len(events) == 0 {
+		events = defaultEvents
+	}
+	uri := c.PrinterURI(name)
+	id, err := c.subscribe(uri, events)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		defer func() {
+			req := c.newRequest(OpCancelSubscription, uri)
+			req.Groups[0].Add("notify-subscription-id", Integer(id))
+			c.Do("/", req)
+		}()
+		send := func(e Event) bool {
+			select {
+			case ch <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		seq := 1
+		for {
+			events, wait, err := c.notifications(uri, id, seq)
+			if err != nil {
+				send(Event{Err: err})
+				return
+			}
+			for _, e := range events {
+				if e.Sequence >= seq {
+					seq = e.Sequence + 1
+				}
+				if !send(e) {
+					return
+				}
+			}
+			t := time.NewTimer(wait)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
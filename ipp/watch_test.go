@@ -0,0 +1,113 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ipp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	var mu sync.Mutex
+	var seqs []int
+	canceled := make(chan int, 1)
+	c, _ := testServer(t, func(req *Message) *Message {
+		mu.Lock()
+		defer mu.Unlock()
+		op := req.Group(TagOperation)
+		resp := response(StatusOK)
+		switch req.Code {
+		case OpCreatePrinterSubscriptions:
+			sub := req.Group(TagSubscription)
+			if sub.String("notify-pull-method") != "ippget" || len(sub.Strings("notify-events")) != len(defaultEvents) {
+				return response(StatusBadRequest)
+			}
+			resp.AddGroup(TagSubscription).Add("notify-subscription-id", Integer(7))
+		case OpGetNotifications:
+			if op.Int("notify-subscription-ids") != 7 {
+				return response(StatusNotFound)
+			}
+			seq := op.Int("notify-sequence-numbers")
+			seqs = append(seqs, seq)
+			if seq != 1 {
+				break
+			}
+			g := resp.AddGroup(TagEvent)
+			g.Add("notify-subscribed-event", String(TagKeyword, "job-created"))
+			g.Add("notify-sequence-number", Integer(1))
+			g.Add("notify-job-id", Integer(42))
+			g.Add("job-state", Enum(JobPending))
+			g = resp.AddGroup(TagEvent)
+			g.Add("notify-subscribed-event", String(TagKeyword, "printer-state-changed"))
+			g.Add("notify-sequence-number", Integer(2))
+			g.Add("printer-state", Enum(PrinterProcessing))
+			g.Add("notify-text", String(TagText, "printing"))
+		case OpCancelSubscription:
+			canceled <- op.Int("notify-subscription-id")
+		default:
+			return response(StatusNotSupported)
+		}
+		return resp
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := c.Watch(ctx, "laser", nil)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	e := <-events
+	if e.Name != "job-created" || e.JobID != 42 || e.JobState != JobPending || e.Sequence != 1 {
+		t.Errorf("unexpected first event %+v", e)
+	}
+	e = <-events
+	if e.Name != "printer-state-changed" || e.PrinterState != PrinterProcessing || e.Text != "printing" {
+		t.Errorf("unexpected second event %+v", e)
+	}
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	for e := range events {
+		t.Errorf("unexpected event %+v", e)
+	}
+	select {
+	case id := <-canceled:
+		if id != 7 {
+			t.Errorf("canceled subscription %d, want 7", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("subscription is not canceled")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seqs) < 2 || seqs[0] != 1 || seqs[1] != 3 {
+		t.Errorf("requested sequence numbers %v, want 1, 3, ...", seqs)
+	}
+}
+
+func TestWatchError(t *testing.T) {
+	c, _ := testServer(t, func(req *Message) *Message {
+		if req.Code == OpCreatePrinterSubscriptions {
+			resp := response(StatusOK)
+			resp.AddGroup(TagSubscription).Add("notify-subscription-id", Integer(1))
+			return resp
+		}
+		return response(StatusNotFound)
+	})
+	events, err := c.Watch(context.Background(), "laser", []string{"job-completed"})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	e, ok := <-events
+	if !ok || !isStatus(e.Err, StatusNotFound) {
+		t.Errorf("got event %+v, want error", e)
+	}
+	if _, ok := <-events; ok {
+		t.Error("channel is not closed after error")
+	}
+}
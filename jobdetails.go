@@ -0,0 +1,168 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"encoding/binary"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+type JOB_INFO_2 struct {
+	JobID              uint32
+	PrinterName        *uint16
+	MachineName        *uint16
+	UserName           *uint16
+	Document           *uint16
+	NotifyName         *uint16
+	DataType           *uint16
+	PrintProcessor     *uint16
+	Parameters         *uint16
+	DriverName         *uint16
+	DevMode            *byte
+	Status             *uint16
+	SecurityDescriptor uintptr
+	StatusCode         uint32
+	Priority           uint32
+	Position           uint32
+	StartTime          uint32
+	UntilTime          uint32
+	TotalPages         uint32
+	Size               uint32
+	Submitted          syscall.Systemtime
+	Time               uint32
+	PagesPrinted       uint32
+}
+
+type JOB_INFO_4 struct {
+	JobID              uint32
+	PrinterName        *uint16
+	MachineName        *uint16
+	UserName           *uint16
+	Document           *uint16
+	NotifyName         *uint16
+	DataType           *uint16
+	PrintProcessor     *uint16
+	Parameters         *uint16
+	DriverName         *uint16
+	DevMode            *byte
+	Status             *uint16
+	SecurityDescriptor uintptr
+	StatusCode         uint32
+	Priority           uint32
+	Position           uint32
+	StartTime          uint32
+	UntilTime          uint32
+	TotalPages         uint32
+	Size               uint32
+	Submitted          syscall.Systemtime
+	Time               uint32
+	PagesPrinted       uint32
+	SizeHigh           int32
+}
+
+// JobDetails stores detailed information about a print job. Jobs of
+// CUPS printers are described by ipp.JobDetails instead.
+type JobDetails struct {
+	JobInfo
+	PrinterName    string
+	NotifyName     string
+	PrintProcessor string
+	Parameters     string
+	DriverName     string
+	Size           int64         // job size in bytes
+	Elapsed        time.Duration // time since job started printing
+	StartTime      time.Duration // job can be printed after StartTime past midnight UTC
+	UntilTime      time.Duration // job can be printed until UntilTime past midnight UTC
+	DevMode        *DevMode      // job settings, or nil
+}
+
+// devModeBytes returns DEVMODE structure (including
+// driver private data) that p points to.
+func devModeBytes(p *byte) []byte {
+	hdr := (*[72]byte)(unsafe.Pointer(p))
+	n := int(binary.LittleEndian.Uint16(hdr[68:])) + int(binary.LittleEndian.Uint16(hdr[70:]))
+	return (*[1 << 20]byte)(unsafe.Pointer(p))[:n:n]
+}
+
+// newJobDetails converts JOB_INFO_2 j into JobDetails. sizeHigh is
+// JOB_INFO_4 SizeHigh field, or 0 for JOB_INFO_2.
+func newJobDetails(j *JOB_INFO_2, sizeHigh int32) *JobDetails {
+	jd := &JobDetails{
+		JobInfo: newJobInfo(&JOB_INFO_1{
+			JobID:        j.JobID,
+			PrinterName:  j.PrinterName,
+			MachineName:  j.MachineName,
+			UserName:     j.UserName,
+			Document:     j.Document,
+			DataType:     j.DataType,
+			Status:       j.Status,
+			StatusCode:   j.StatusCode,
+			Priority:     j.Priority,
+			Position:     j.Position,
+			TotalPages:   j.TotalPages,
+			PagesPrinted: j.PagesPrinted,
+			Submitted:    j.Submitted,
+		}),
+		PrinterName:    utf16PtrToString(j.PrinterName),
+		NotifyName:     utf16PtrToString(j.NotifyName),
+		PrintProcessor: utf16PtrToString(j.PrintProcessor),
+		Parameters:     utf16PtrToString(j.Parameters),
+		DriverName:     utf16PtrToString(j.DriverName),
+		Size:           int64(sizeHigh)<<32 | int64(j.Size),
+		Elapsed:        time.Duration(j.Time) * time.Millisecond,
+		StartTime:      time.Duration(j.StartTime) * time.Minute,
+		UntilTime:      time.Duration(j.UntilTime) * time.Minute,
+	}
+	if j.DevMode != nil {
+		dm, err := ParseDevMode(devModeBytes(j.DevMode))
+		if err == nil {
+			jd.DevMode = dm
+		}
+	}
+	return jd
+}
+
+// decodeJobDetails converts JOB_INFO_2 or JOB_INFO_4 (depending
+// on level) structure stored in buf into JobDetails.
+func decodeJobDetails(buf []byte, level uint32) *JobDetails {
+	var sizeHigh int32
+	if level == 4 {
+		sizeHigh = (*JOB_INFO_4)(unsafe.Pointer(&buf[0])).SizeHigh
+	}
+	// JOB_INFO_4 starts with the same fields as JOB_INFO_2.
+	return newJobDetails((*JOB_INFO_2)(unsafe.Pointer(&buf[0])), sizeHigh)
+}
+
+// JobDetails returns detailed information about print job id.
+func (p *Printer) JobDetails(id uint32) (*JobDetails, error) {
+	var needed uint32
+	level := uint32(4)
+	buf := make([]byte, 1)
+	for {
+		err := GetJob(p.h, id, level, &buf[0], uint32(len(buf)), &needed)
+		if err == nil {
+			break
+		}
+		if err == windows.ERROR_INVALID_LEVEL && level == 4 {
+			level = 2
+			continue
+		}
+		if err != syscall.ERROR_INSUFFICIENT_BUFFER {
+			return nil, err
+		}
+		if needed <= uint32(len(buf)) {
+			return nil, err
+		}
+		buf = make([]byte, needed)
+	}
+	return decodeJobDetails(buf, level), nil
+}
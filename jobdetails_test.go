@@ -0,0 +1,97 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestDecodeJobDetails(t *testing.T) {
+	s := syscall.StringToUTF16Ptr
+	dm := makeDevMode(devModeSize, 8)
+	ji := JOB_INFO_4{
+		JobID:          7,
+		PrinterName:    s("Office"),
+		MachineName:    s(`\\PC1`),
+		UserName:       s("alex"),
+		Document:       s("report.pdf"),
+		NotifyName:     s("alex"),
+		DataType:       s("RAW"),
+		PrintProcessor: s("winprint"),
+		Parameters:     s(""),
+		DriverName:     s("HP Universal Printing PCL 6"),
+		DevMode:        &dm[0],
+		StatusCode:     JOB_STATUS_PRINTING,
+		Priority:       1,
+		Position:       1,
+		StartTime:      60,
+		UntilTime:      120,
+		TotalPages:     10,
+		Size:           0x10,
+		Submitted:      syscall.Systemtime{Year: 2026, Month: 10, Day: 18, Hour: 12},
+		Time:           1500,
+		PagesPrinted:   4,
+		SizeHigh:       1,
+	}
+	buf := asBytes(unsafe.Pointer(&ji), unsafe.Sizeof(ji))
+
+	jd := decodeJobDetails(buf, 4)
+	if jd.JobID != 7 || jd.DocumentName != "report.pdf" || jd.Status != "Printing" || jd.PagesPrinted != 4 {
+		t.Errorf("unexpected JobInfo: %+v", jd.JobInfo)
+	}
+	if jd.PrinterName != "Office" || jd.NotifyName != "alex" || jd.PrintProcessor != "winprint" ||
+		jd.DriverName != "HP Universal Printing PCL 6" {
+		t.Errorf("unexpected JobDetails: %+v", jd)
+	}
+	if jd.Size != 1<<32+0x10 {
+		t.Errorf("Size is %d, want %d", jd.Size, int64(1<<32+0x10))
+	}
+	if jd.Elapsed != 1500*time.Millisecond || jd.StartTime != time.Hour || jd.UntilTime != 2*time.Hour {
+		t.Errorf("unexpected times: %v %v %v", jd.Elapsed, jd.StartTime, jd.UntilTime)
+	}
+	if jd.DevMode == nil || jd.DevMode.Copies != 3 || len(jd.DevMode.Raw) != devModeSize+8 {
+		t.Errorf("unexpected DevMode: %+v", jd.DevMode)
+	}
+
+	jd = decodeJobDetails(buf, 2)
+	if jd.Size != 0x10 {
+		t.Errorf("JOB_INFO_2 Size is %d, want %d", jd.Size, 0x10)
+	}
+	runtime.KeepAlive(dm)
+}
+
+func TestJobDetails(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	jobs, err := p.Jobs()
+	if err != nil {
+		t.Fatalf("Jobs failed: %v", err)
+	}
+	for _, j := range jobs {
+		jd, err := p.JobDetails(j.JobID)
+		if err != nil {
+			// job might have completed already
+			t.Logf("JobDetails(%d) failed: %v", j.JobID, err)
+			continue
+		}
+		t.Logf("%+v", jd)
+	}
+}
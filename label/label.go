@@ -0,0 +1,318 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package label describes labels independently of printer language,
+// so the same label can be printed on ZPL, EPL2 and TSPL printers.
+//
+// All positions and sizes are in printer dots, with origin in the top
+// left corner of the label.
+package label
+
+import (
+	"errors"
+
+	"github.com/alexbrainman/printer/bitmap"
+	"github.com/alexbrainman/printer/epl"
+	"github.com/alexbrainman/printer/tspl"
+	"github.com/alexbrainman/printer/zpl"
+)
+
+// Label describes single label.
+type Label struct {
+	Width, Height int // label size
+	Gap           int // distance between labels
+	DPI           int // printer resolution; 203, if zero
+	Copies        int // 1, if zero
+	Items         []Item
+}
+
+// Item is an element printed on label: *Text, *Box, *Barcode,
+// *QR or *Image.
+type Item interface {
+	item()
+}
+
+// Rotation is text rotation clockwise.
+type Rotation int
+
+const (
+	Rotate0 Rotation = iota
+	Rotate90
+	Rotate180
+	Rotate270
+)
+
+// Text is single line of text. Printer languages use different
+// fonts, so text looks similar, but not the same on every printer.
+// EPL2 and TSPL only print ASCII text.
+type Text struct {
+	X, Y     int
+	Height   int // character height
+	Rotation Rotation
+	Text     string
+}
+
+// Box is rectangle with border of Thickness dots. Thickness of half
+// of the smallest side, or more, makes the rectangle filled.
+type Box struct {
+	X, Y, Width, Height int
+	Thickness           int
+}
+
+// BarcodeType is 1D barcode symbology.
+type BarcodeType int
+
+const (
+	Code128 BarcodeType = iota
+	Code39
+	EAN13
+)
+
+// Barcode is 1D barcode.
+type Barcode struct {
+	X, Y        int
+	Type        BarcodeType
+	Data        string
+	Height      int
+	ModuleWidth int  // narrow bar width; 2, if zero
+	HRI         bool // print human readable text below barcode
+}
+
+// QR is QR code.
+type QR struct {
+	X, Y       int
+	Data       string
+	ModuleSize int  // 4, if zero
+	Level      byte // error correction level 'L', 'M', 'Q' or 'H'; 'M', if zero
+}
+
+// Image is monochrome image printed one pixel per dot.
+type Image struct {
+	X, Y   int
+	Bitmap *bitmap.Bitmap
+}
+
+func (*Text) item()    {}
+func (*Box) item()     {}
+func (*Barcode) item() {}
+func (*QR) item()      {}
+func (*Image) item()   {}
+
+var ErrItem = errors.New("label: unsupported label item")
+
+func (l *Label) copies() int {
+	if l.Copies <= 0 {
+		return 1
+	}
+	return l.Copies
+}
+
+func (l *Label) dpi() int {
+	if l.DPI <= 0 {
+		return 203
+	}
+	return l.DPI
+}
+
+func (b *Barcode) moduleWidth() int {
+	if b.ModuleWidth <= 0 {
+		return 2
+	}
+	return b.ModuleWidth
+}
+
+func (q *QR) moduleSize() int {
+	if q.ModuleSize <= 0 {
+		return 4
+	}
+	return q.ModuleSize
+}
+
+func (q *QR) level() byte {
+	switch q.Level {
+	case 'L', 'M', 'Q', 'H':
+		return q.Level
+	}
+	return 'M'
+}
+
+// filled reports whether box b is drawn filled.
+func (b *Box) filled() bool {
+	min := b.Width
+	if b.Height < min {
+		min = b.Height
+	}
+	return b.Thickness*2 >= min
+}
+
+// bitmapFont is fixed size printer font.
+type bitmapFont struct {
+	name   int
+	height int // in dots at 203 dpi
+}
+
+// chooseFont returns font and magnification that produce text
+// height closest to height.
+func chooseFont(fonts []bitmapFont, height, maxMul int) (font bitmapFont, mul int) {
+	best := -1
+	// Prefer smaller magnification, as magnified text is coarse.
+	for m := 1; m <= maxMul; m++ {
+		for _, f := range fonts {
+			d := f.height*m - height
+			if d < 0 {
+				d = -d
+			}
+			if best < 0 || d < best {
+				best, font, mul = d, f, m
+			}
+		}
+	}
+	return font, mul
+}
+
+// ZPL returns label l as ZPL II commands.
+func (l *Label) ZPL() ([]byte, error) {
+	b := zpl.New()
+	b.Start()
+	b.LabelSize(l.Width, l.Height)
+	for _, it := range l.Items {
+		switch it := it.(type) {
+		case *Text:
+			b.SetOrientation([]zpl.Orientation{zpl.Normal, zpl.Rotated, zpl.Inverted, zpl.Bottom}[it.Rotation&3])
+			b.SetFont('0', it.Height, it.Height)
+			b.Text(it.X, it.Y, it.Text)
+		case *Box:
+			t := it.Thickness
+			if it.filled() {
+				t = it.Width
+				if it.Height < t {
+					t = it.Height
+				}
+			}
+			b.Box(it.X, it.Y, it.Width, it.Height, t)
+		case *Barcode:
+			b.SetBarcode(it.moduleWidth(), 3, it.Height)
+			switch it.Type {
+			case Code128:
+				b.Code128(it.X, it.Y, it.Data, it.Height, it.HRI)
+			case Code39:
+				b.Code39(it.X, it.Y, it.Data, it.Height, it.HRI)
+			case EAN13:
+				b.EAN13(it.X, it.Y, it.Data, it.Height, it.HRI)
+			default:
+				return nil, ErrItem
+			}
+		case *QR:
+			b.QR(it.X, it.Y, it.Data, it.moduleSize(), zpl.QRLevel(it.level()))
+		case *Image:
+			b.Bitmap(it.X, it.Y, it.Bitmap)
+		default:
+			return nil, ErrItem
+		}
+	}
+	b.SetOrientation(zpl.Normal)
+	b.Quantity(l.copies())
+	b.End()
+	return b.Bytes(), nil
+}
+
+// eplFonts lists EPL2 fonts 1 to 4. Font 5 only has capital letters.
+var eplFonts = []bitmapFont{{1, 12}, {2, 16}, {3, 20}, {4, 24}}
+
+// EPL returns label l as EPL2 commands.
+func (l *Label) EPL() ([]byte, error) {
+	b := epl.New()
+	b.Start()
+	b.LabelSize(l.Width, l.Height, l.Gap)
+	for _, it := range l.Items {
+		switch it := it.(type) {
+		case *Text:
+			f, m := chooseFont(eplFonts, it.Height*203/l.dpi(), 6)
+			b.Text(it.X, it.Y, epl.Rotation(it.Rotation&3), f.name, m, m, false, it.Text)
+		case *Box:
+			if it.filled() {
+				b.Line(it.X, it.Y, it.Width, it.Height)
+			} else {
+				b.Box(it.X, it.Y, it.Width, it.Height, it.Thickness)
+			}
+		case *Barcode:
+			var typ string
+			switch it.Type {
+			case Code128:
+				typ = epl.Code128
+			case Code39:
+				typ = epl.Code39
+			case EAN13:
+				typ = epl.EAN13
+			default:
+				return nil, ErrItem
+			}
+			w := it.moduleWidth()
+			b.Barcode(it.X, it.Y, epl.Rotate0, typ, w, w*3, it.Height, it.HRI, it.Data)
+		case *QR:
+			b.QR(it.X, it.Y, it.moduleSize(), it.level(), it.Data)
+		case *Image:
+			b.Bitmap(it.X, it.Y, it.Bitmap)
+		default:
+			return nil, ErrItem
+		}
+	}
+	b.Print(l.copies())
+	return b.Bytes(), nil
+}
+
+// tsplFonts lists TSPL fonts 1 to 5.
+var tsplFonts = []bitmapFont{{1, 12}, {2, 20}, {3, 24}, {4, 32}, {5, 48}}
+
+// TSPL returns label l as TSPL commands.
+func (l *Label) TSPL() ([]byte, error) {
+	b := tspl.New()
+	mm := func(dots int) float64 {
+		// Truncate to 0.1 mm.
+		return float64(dots*254/l.dpi()) / 10
+	}
+	b.Size(mm(l.Width), mm(l.Height))
+	b.Gap(mm(l.Gap), 0)
+	b.Direction(0)
+	b.Clear()
+	for _, it := range l.Items {
+		switch it := it.(type) {
+		case *Text:
+			f, m := chooseFont(tsplFonts, it.Height*203/l.dpi(), 10)
+			b.Text(it.X, it.Y, string(rune('0'+f.name)), int(it.Rotation&3)*90, m, m, it.Text)
+		case *Box:
+			if it.filled() {
+				b.Bar(it.X, it.Y, it.Width, it.Height)
+			} else {
+				b.Box(it.X, it.Y, it.Width, it.Height, it.Thickness)
+			}
+		case *Barcode:
+			var typ string
+			switch it.Type {
+			case Code128:
+				typ = tspl.Code128
+			case Code39:
+				typ = tspl.Code39
+			case EAN13:
+				typ = tspl.EAN13
+			default:
+				return nil, ErrItem
+			}
+			hri := tspl.HRINone
+			if it.HRI {
+				hri = tspl.HRICenter
+			}
+			w := it.moduleWidth()
+			b.Barcode(it.X, it.Y, typ, it.Height, hri, 0, w, w*3, it.Data)
+		case *QR:
+			b.QRCode(it.X, it.Y, it.level(), it.moduleSize(), 0, it.Data)
+		case *Image:
+			b.Bitmap(it.X, it.Y, it.Bitmap)
+		default:
+			return nil, ErrItem
+		}
+	}
+	b.Print(l.copies())
+	return b.Bytes(), nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package label
+
+import (
+	"testing"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+func testLabel() *Label {
+	bm := bitmap.New(8, 1)
+	bm.Set(0, 0, true)
+	return &Label{
+		Width:  812,
+		Height: 406,
+		Gap:    24,
+		Copies: 2,
+		Items: []Item{
+			&Text{X: 20, Y: 20, Height: 48, Text: "Hello"},
+			&Text{X: 700, Y: 20, Height: 22, Rotation: Rotate90, Text: "side"},
+			&Box{X: 10, Y: 10, Width: 792, Height: 386, Thickness: 3},
+			&Box{X: 10, Y: 100, Width: 792, Height: 4, Thickness: 2},
+			&Barcode{X: 20, Y: 120, Type: Code128, Data: "ABC123", Height: 80, HRI: true},
+			&QR{X: 600, Y: 120, Data: "https://example.com"},
+			&Image{X: 20, Y: 300, Bitmap: bm},
+		},
+	}
+}
+
+func TestZPL(t *testing.T) {
+	got, err := testLabel().ZPL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "^XA^CI28^PW812^LL406" +
+		"^FWN^CF0,48,48^FO20,20^FH^FDHello^FS" +
+		"^FWR^CF0,22,22^FO700,20^FH^FDside^FS" +
+		"^FO10,10^GB792,386,3^FS" +
+		"^FO10,100^GB792,4,4^FS" +
+		"^BY2,3.0,80^FO20,120^BCN,80,Y,N,N^FH^FDABC123^FS" +
+		"^FO600,120^BQN,2,4^FH^FDMA,https://example.com^FS" +
+		"^FO20,300^GFA,1,1,1,:Z64:eJwAAQD+/4ADAACBAIE=:66FF^FS" +
+		"^FWN^PQ2^XZ"
+	if string(got) != want {
+		t.Errorf("unexpected ZPL:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestEPL(t *testing.T) {
+	got, err := testLabel().EPL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\nN\nq812\nQ406,24\n" +
+		"A20,20,0,4,2,2,N,\"Hello\"\n" +
+		"A700,20,1,3,1,1,N,\"side\"\n" +
+		"X10,10,3,802,396\n" +
+		"LO10,100,792,4\n" +
+		"B20,120,0,1,2,6,80,B,\"ABC123\"\n" +
+		"b600,120,Q,m2,s4,eM,\"https://example.com\"\n" +
+		"GW20,300,1,1,\x7f\n" +
+		"P2\n"
+	if string(got) != want {
+		t.Errorf("unexpected EPL2:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestTSPL(t *testing.T) {
+	got, err := testLabel().TSPL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "SIZE 101.6 mm,50.8 mm\r\nGAP 3 mm,0 mm\r\nDIRECTION 0\r\nCLS\r\n" +
+		"TEXT 20,20,\"5\",0,1,1,\"Hello\"\r\n" +
+		"TEXT 700,20,\"2\",90,1,1,\"side\"\r\n" +
+		"BOX 10,10,802,396,3\r\n" +
+		"BAR 10,100,792,4\r\n" +
+		"BARCODE 20,120,\"128\",80,2,0,2,6,\"ABC123\"\r\n" +
+		"QRCODE 600,120,M,4,A,0,\"https://example.com\"\r\n" +
+		"BITMAP 20,300,1,1,0,\x7f\r\n" +
+		"PRINT 1,2\r\n"
+	if string(got) != want {
+		t.Errorf("unexpected TSPL:\n%q\nwant\n%q", got, want)
+	}
+}
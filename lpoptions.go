@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package printer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexbrainman/printer/ipp"
+)
+
+// systemLpoptions is CUPS system wide lpoptions file.
+var systemLpoptions = "/etc/cups/lpoptions"
+
+// cupsClient returns client of CUPS server. Tests replace it.
+var cupsClient = ipp.NewCUPSClient
+
+// userLpoptions returns path of current user lpoptions file.
+func userLpoptions() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cups", "lpoptions"), nil
+}
+
+// readLpoptionsDefault returns printer name set by "Default" line
+// of lpoptions file path. It returns empty string, if file does not
+// exist or has no "Default" line.
+func readLpoptionsDefault(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		f := strings.Fields(s.Text())
+		if len(f) >= 2 && strings.EqualFold(f[0], "Default") {
+			return f[1], nil
+		}
+	}
+	return "", s.Err()
+}
+
+// writeLpoptionsDefault changes lpoptions file path to make printer
+// name default. Previous default printer becomes ordinary "Dest" line,
+// so its options are preserved.
+func writeLpoptionsDefault(path, name string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var out bytes.Buffer
+	found := false
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		line := s.Text()
+		f := strings.Fields(line)
+		if len(f) >= 2 && (strings.EqualFold(f[0], "Default") || strings.EqualFold(f[0], "Dest")) {
+			f[0] = "Dest"
+			if f[1] == name {
+				f[0] = "Default"
+				found = true
+			}
+			line = strings.Join(f, " ")
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if !found {
+		out.WriteString("Default " + name + "\n")
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, out.Bytes(), 0644)
+}
+
+// Default returns default printer name. Like CUPS, it uses LPDEST
+// and PRINTER environment variables first, then user and system
+// lpoptions files, and then asks CUPS server for its default printer
+// (see ipp.NewCUPSClient).
+func Default() (string, error) {
+	if name := os.Getenv("LPDEST"); name != "" {
+		return name, nil
+	}
+	// CUPS ignores PRINTER=lp, because it is often set by default.
+	if name := os.Getenv("PRINTER"); name != "" && name != "lp" {
+		return name, nil
+	}
+	paths := []string{systemLpoptions}
+	if path, err := userLpoptions(); err == nil {
+		paths = append([]string{path}, paths...)
+	}
+	for _, path := range paths {
+		name, err := readLpoptionsDefault(path)
+		if err != nil {
+			return "", err
+		}
+		if name != "" {
+			return name, nil
+		}
+	}
+	name, err := cupsClient().Default()
+	if err == ipp.ErrNoDefault {
+		return "", errors.New("printer: no default printer set")
+	}
+	return name, err
+}
+
+// SetDefault makes printer name default printer for current user
+// by changing "Default" line of ~/.cups/lpoptions file.
+func SetDefault(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t\r\n") {
+		return fmt.Errorf("printer: invalid printer name %q", name)
+	}
+	path, err := userLpoptions()
+	if err != nil {
+		return err
+	}
+	return writeLpoptionsDefault(path, name)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package printer
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexbrainman/printer/ipp"
+)
+
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestLpoptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "printer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".cups", "lpoptions")
+	err = writeLpoptionsDefault(path, "first")
+	if err != nil {
+		t.Fatalf("writeLpoptionsDefault failed: %v", err)
+	}
+	err = ioutil.WriteFile(path, []byte("Default first sides=two-sided-long-edge\nDest second media=iso_a4_210x297mm\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeLpoptionsDefault(path, "second")
+	if err != nil {
+		t.Fatalf("writeLpoptionsDefault failed: %v", err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Dest first sides=two-sided-long-edge\nDefault second media=iso_a4_210x297mm\n"
+	if string(b) != want {
+		t.Fatalf("lpoptions file is %q, want %q", b, want)
+	}
+	err = writeLpoptionsDefault(path, "third")
+	if err != nil {
+		t.Fatalf("writeLpoptionsDefault failed: %v", err)
+	}
+	name, err := readLpoptionsDefault(path)
+	if err != nil {
+		t.Fatalf("readLpoptionsDefault failed: %v", err)
+	}
+	if name != "third" {
+		t.Fatalf("default printer is %q, want %q", name, "third")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	dir, err := ioutil.TempDir("", "printer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldSystem := systemLpoptions
+	systemLpoptions = filepath.Join(dir, "system-lpoptions")
+	defer func() { systemLpoptions = oldSystem }()
+	setenv(t, "HOME", dir)
+	setenv(t, "LPDEST", "")
+	setenv(t, "PRINTER", "lp")
+
+	serverDefault := ""
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := ipp.Decode(r.Body)
+		if err != nil || req.Code != ipp.OpCUPSGetDefault {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		// Response has the same operation attributes as request.
+		resp := ipp.NewRequest(0)
+		resp.Code = ipp.StatusNotFound
+		if serverDefault != "" {
+			resp.Code = ipp.StatusOK
+			resp.AddGroup(ipp.TagPrinter).Add("printer-name", ipp.String(ipp.TagName, serverDefault))
+		}
+		resp.RequestID = req.RequestID
+		w.Write(resp.Encode())
+	}))
+	defer s.Close()
+	oldClient := cupsClient
+	cupsClient = func() *ipp.Client { return &ipp.Client{URL: s.URL} }
+	defer func() { cupsClient = oldClient }()
+
+	if name, err := Default(); err == nil {
+		t.Fatalf("Default returned %q, want error", name)
+	}
+	check := func(want string) {
+		t.Helper()
+		name, err := Default()
+		if err != nil {
+			t.Fatalf("Default failed: %v", err)
+		}
+		if name != want {
+			t.Fatalf("Default returned %q, want %q", name, want)
+		}
+	}
+	serverDefault = "server"
+	check("server")
+	err = ioutil.WriteFile(systemLpoptions, []byte("Default system\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("system")
+	err = SetDefault("mine")
+	if err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+	check("mine")
+	setenv(t, "PRINTER", "printer")
+	check("printer")
+	setenv(t, "LPDEST", "lpdest")
+	check("lpdest")
+
+	if err := SetDefault("bad name"); err == nil {
+		t.Fatalf("SetDefault accepted invalid printer name")
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package media provides a catalog of paper sizes named according to
+// PWG 5101.1 self-describing media names, like "iso_a4_210x297mm" or
+// "na_letter_8.5x11in".
+package media
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Size describes paper size. Width and Height are in hundredths of
+// a millimeter, the unit used by IPP and PWG standards.
+type Size struct {
+	Name   string // PWG self-describing media name
+	Width  int
+	Height int
+}
+
+// Points returns s width and height in PostScript points (1/72 inch).
+func (s Size) Points() (width, height float64) {
+	return float64(s.Width) * 72 / 2540, float64(s.Height) * 72 / 2540
+}
+
+// Landscape returns s with width and height swapped.
+func (s Size) Landscape() Size {
+	s.Width, s.Height = s.Height, s.Width
+	return s
+}
+
+// Common sizes.
+var (
+	A4     = mustParse("iso_a4_210x297mm")
+	Letter = mustParse("na_letter_8.5x11in")
+)
+
+// names lists all sizes in the catalog.
+var names = []string{
+	"iso_a0_841x1189mm",
+	"iso_a1_594x841mm",
+	"iso_a2_420x594mm",
+	"iso_a3_297x420mm",
+	"iso_a4_210x297mm",
+	"iso_a5_148x210mm",
+	"iso_a6_105x148mm",
+	"iso_a7_74x105mm",
+	"iso_a8_52x74mm",
+	"iso_a9_37x52mm",
+	"iso_a10_26x37mm",
+	"iso_b3_353x500mm",
+	"iso_b4_250x353mm",
+	"iso_b5_176x250mm",
+	"iso_b6_125x176mm",
+	"iso_c3_324x458mm",
+	"iso_c4_229x324mm",
+	"iso_c5_162x229mm",
+	"iso_c6_114x162mm",
+	"iso_c6c5_114x229mm",
+	"iso_dl_110x220mm",
+	"jis_b4_257x364mm",
+	"jis_b5_182x257mm",
+	"jis_b6_128x182mm",
+	"jpn_hagaki_100x148mm",
+	"jpn_oufuku_148x200mm",
+	"na_letter_8.5x11in",
+	"na_legal_8.5x14in",
+	"na_ledger_11x17in",
+	"na_invoice_5.5x8.5in",
+	"na_executive_7.25x10.5in",
+	"na_foolscap_8.5x13in",
+	"na_quarto_8.5x10.83in",
+	"na_govt-letter_8x10in",
+	"na_9x11_9x11in",
+	"na_10x11_10x11in",
+	"na_10x14_10x14in",
+	"na_11x15_11x15in",
+	"na_c_17x22in",
+	"na_d_22x34in",
+	"na_e_34x44in",
+	"na_arch-a_9x12in",
+	"na_arch-b_12x18in",
+	"na_index-3x5_3x5in",
+	"na_index-4x6_4x6in",
+	"na_index-5x8_5x8in",
+	"na_5x7_5x7in",
+	"na_monarch_3.875x7.5in",
+	"na_personal_3.625x6.5in",
+	"na_number-9_3.875x8.875in",
+	"na_number-10_4.125x9.5in",
+	"na_number-11_4.5x10.375in",
+	"na_number-12_4.75x11in",
+	"na_number-14_5x11.5in",
+	"na_fanfold-eur_8.5x12in",
+	"na_fanfold-us_11x14.875in",
+	"om_italian_110x230mm",
+	"om_invite_220x220mm",
+	"om_small-photo_100x150mm",
+}
+
+// dmpaper maps Windows DMPAPER_* values to PWG media names.
+var dmpaper = map[int]string{
+	1:  "na_letter_8.5x11in",        // DMPAPER_LETTER
+	2:  "na_letter_8.5x11in",        // DMPAPER_LETTERSMALL
+	3:  "na_ledger_11x17in",         // DMPAPER_TABLOID
+	4:  "na_ledger_11x17in",         // DMPAPER_LEDGER
+	5:  "na_legal_8.5x14in",         // DMPAPER_LEGAL
+	6:  "na_invoice_5.5x8.5in",      // DMPAPER_STATEMENT
+	7:  "na_executive_7.25x10.5in",  // DMPAPER_EXECUTIVE
+	8:  "iso_a3_297x420mm",          // DMPAPER_A3
+	9:  "iso_a4_210x297mm",          // DMPAPER_A4
+	10: "iso_a4_210x297mm",          // DMPAPER_A4SMALL
+	11: "iso_a5_148x210mm",          // DMPAPER_A5
+	12: "jis_b4_257x364mm",          // DMPAPER_B4
+	13: "jis_b5_182x257mm",          // DMPAPER_B5
+	14: "na_foolscap_8.5x13in",      // DMPAPER_FOLIO
+	15: "na_quarto_8.5x10.83in",     // DMPAPER_QUARTO
+	16: "na_10x14_10x14in",          // DMPAPER_10X14
+	17: "na_ledger_11x17in",         // DMPAPER_11X17
+	18: "na_letter_8.5x11in",        // DMPAPER_NOTE
+	19: "na_number-9_3.875x8.875in", // DMPAPER_ENV_9
+	20: "na_number-10_4.125x9.5in",  // DMPAPER_ENV_10
+	21: "na_number-11_4.5x10.375in", // DMPAPER_ENV_11
+	22: "na_number-12_4.75x11in",    // DMPAPER_ENV_12
+	23: "na_number-14_5x11.5in",     // DMPAPER_ENV_14
+	24: "na_c_17x22in",              // DMPAPER_CSHEET
+	25: "na_d_22x34in",              // DMPAPER_DSHEET
+	26: "na_e_34x44in",              // DMPAPER_ESHEET
+	27: "iso_dl_110x220mm",          // DMPAPER_ENV_DL
+	28: "iso_c5_162x229mm",          // DMPAPER_ENV_C5
+	29: "iso_c3_324x458mm",          // DMPAPER_ENV_C3
+	30: "iso_c4_229x324mm",          // DMPAPER_ENV_C4
+	31: "iso_c6_114x162mm",          // DMPAPER_ENV_C6
+	32: "iso_c6c5_114x229mm",        // DMPAPER_ENV_C65
+	33: "iso_b4_250x353mm",          // DMPAPER_ENV_B4
+	34: "iso_b5_176x250mm",          // DMPAPER_ENV_B5
+	35: "iso_b6_125x176mm",          // DMPAPER_ENV_B6
+	36: "om_italian_110x230mm",      // DMPAPER_ENV_ITALY
+	37: "na_monarch_3.875x7.5in",    // DMPAPER_ENV_MONARCH
+	38: "na_personal_3.625x6.5in",   // DMPAPER_ENV_PERSONAL
+	39: "na_fanfold-us_11x14.875in", // DMPAPER_FANFOLD_US
+	40: "na_fanfold-eur_8.5x12in",   // DMPAPER_FANFOLD_STD_GERMAN
+	41: "na_foolscap_8.5x13in",      // DMPAPER_FANFOLD_LGL_GERMAN
+	42: "iso_b4_250x353mm",          // DMPAPER_ISO_B4
+	43: "jpn_hagaki_100x148mm",      // DMPAPER_JAPANESE_POSTCARD
+	44: "na_9x11_9x11in",            // DMPAPER_9X11
+	45: "na_10x11_10x11in",          // DMPAPER_10X11
+	46: "na_11x15_11x15in",          // DMPAPER_15X11
+	47: "om_invite_220x220mm",       // DMPAPER_ENV_INVITE
+	66: "iso_a2_420x594mm",          // DMPAPER_A2
+	69: "jpn_oufuku_148x200mm",      // DMPAPER_DBL_JAPANESE_POSTCARD
+	70: "iso_a6_105x148mm",          // DMPAPER_A6
+	88: "jis_b6_128x182mm",          // DMPAPER_B6_JIS
+}
+
+var catalog = make(map[string]Size)
+
+func init() {
+	for _, name := range names {
+		catalog[name] = mustParse(name)
+	}
+}
+
+func mustParse(name string) Size {
+	s, err := Parse(name)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Sizes returns all sizes in the catalog.
+func Sizes() []Size {
+	sizes := make([]Size, 0, len(names))
+	for _, name := range names {
+		sizes = append(sizes, catalog[name])
+	}
+	return sizes
+}
+
+// Parse extracts media dimensions from PWG self-describing media
+// name, like "custom_label_102x152mm". The name does not have to
+// be present in the catalog.
+func Parse(name string) (Size, error) {
+	i := strings.LastIndexByte(name, '_')
+	if i < 0 {
+		return Size{}, errors.New("media: invalid media name " + strconv.Quote(name))
+	}
+	dims := name[i+1:]
+	var unit float64
+	switch {
+	case strings.HasSuffix(dims, "mm"):
+		unit = 100
+	case strings.HasSuffix(dims, "in"):
+		unit = 2540
+	default:
+		return Size{}, errors.New("media: unknown units in media name " + strconv.Quote(name))
+	}
+	dims = dims[:len(dims)-2]
+	j := strings.IndexByte(dims, 'x')
+	if j < 0 {
+		return Size{}, errors.New("media: invalid dimensions in media name " + strconv.Quote(name))
+	}
+	w, err := strconv.ParseFloat(dims[:j], 64)
+	if err != nil || w <= 0 {
+		return Size{}, errors.New("media: invalid width in media name " + strconv.Quote(name))
+	}
+	h, err := strconv.ParseFloat(dims[j+1:], 64)
+	if err != nil || h <= 0 {
+		return Size{}, errors.New("media: invalid height in media name " + strconv.Quote(name))
+	}
+	return Size{
+		Name:   name,
+		Width:  int(math.Round(w * unit)),
+		Height: int(math.Round(h * unit)),
+	}, nil
+}
+
+// Lookup returns media size by name. The name is either full PWG media
+// name, like "iso_a4_210x297mm", or its size name part only, like "a4"
+// or "letter" (case insensitive). Names missing from the catalog are
+// accepted, if they have self-describing dimensions.
+func Lookup(name string) (Size, bool) {
+	if s, ok := catalog[name]; ok {
+		return s, true
+	}
+	for _, n := range names {
+		parts := strings.Split(n, "_")
+		if strings.EqualFold(parts[1], name) {
+			return catalog[n], true
+		}
+	}
+	s, err := Parse(name)
+	if err != nil {
+		return Size{}, false
+	}
+	return s, true
+}
+
+// LookupSize returns catalog media size closest to width x height
+// (in hundredths of a millimeter). Both dimensions must be within
+// tolerance of the catalog size. Landscape sizes match too.
+func LookupSize(width, height, tolerance int) (Size, bool) {
+	if width > height {
+		width, height = height, width
+	}
+	var best Size
+	bestd := -1
+	for _, name := range names {
+		s := catalog[name]
+		dw := abs(s.Width - width)
+		dh := abs(s.Height - height)
+		if dw > tolerance || dh > tolerance {
+			continue
+		}
+		if bestd < 0 || dw+dh < bestd {
+			best, bestd = s, dw+dh
+		}
+	}
+	return best, bestd >= 0
+}
+
+// LookupDMPaper returns media size for Windows DMPAPER_* value id.
+func LookupDMPaper(id int) (Size, bool) {
+	name, ok := dmpaper[id]
+	if !ok {
+		return Size{}, false
+	}
+	return catalog[name], true
+}
+
+// DMPaper returns Windows DMPAPER_* value for media size s,
+// or 0, if there is none.
+func (s Size) DMPaper() int {
+	id := 0
+	for k, v := range dmpaper {
+		if v == s.Name && (id == 0 || k < id) {
+			id = k
+		}
+	}
+	return id
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		width  int
+		height int
+	}{
+		{"iso_a4_210x297mm", "iso_a4_210x297mm", 21000, 29700},
+		{"na_letter_8.5x11in", "na_letter_8.5x11in", 21590, 27940},
+		{"A4", "iso_a4_210x297mm", 21000, 29700},
+		{"letter", "na_letter_8.5x11in", 21590, 27940},
+		{"na_number-10_4.125x9.5in", "na_number-10_4.125x9.5in", 10478, 24130},
+		{"custom_label_102x152mm", "custom_label_102x152mm", 10200, 15200},
+	}
+	for _, test := range tests {
+		s, ok := Lookup(test.name)
+		if !ok {
+			t.Errorf("Lookup(%q) failed", test.name)
+			continue
+		}
+		if s.Name != test.want || s.Width != test.width || s.Height != test.height {
+			t.Errorf("Lookup(%q) = %+v, want %s %dx%d", test.name, s, test.want, test.width, test.height)
+		}
+	}
+	for _, name := range []string{"", "nosuchsize", "custom_bad_10x10cm", "custom_bad_axbmm"} {
+		if s, ok := Lookup(name); ok {
+			t.Errorf("Lookup(%q) = %+v, want failure", name, s)
+		}
+	}
+}
+
+func TestLookupSize(t *testing.T) {
+	tests := []struct {
+		width, height, tolerance int
+		want                     string
+	}{
+		{21000, 29700, 0, "iso_a4_210x297mm"},
+		{29700, 21000, 0, "iso_a4_210x297mm"},
+		{21590, 27940, 0, "na_letter_8.5x11in"},
+		{21600, 27900, 100, "na_letter_8.5x11in"},
+		{21000, 29600, 100, "iso_a4_210x297mm"},
+		{21000, 29600, 50, ""},
+		{1000, 1000, 100, ""},
+	}
+	for _, test := range tests {
+		s, ok := LookupSize(test.width, test.height, test.tolerance)
+		if test.want == "" {
+			if ok {
+				t.Errorf("LookupSize(%d, %d, %d) = %q, want failure", test.width, test.height, test.tolerance, s.Name)
+			}
+			continue
+		}
+		if !ok || s.Name != test.want {
+			t.Errorf("LookupSize(%d, %d, %d) = %q, %v, want %q", test.width, test.height, test.tolerance, s.Name, ok, test.want)
+		}
+	}
+}
+
+func TestDMPaper(t *testing.T) {
+	for id, name := range dmpaper {
+		s, ok := LookupDMPaper(id)
+		if !ok || s.Name != name {
+			t.Errorf("LookupDMPaper(%d) = %q, %v, want %q", id, s.Name, ok, name)
+		}
+		if _, ok := catalog[name]; !ok {
+			t.Errorf("DMPAPER %d name %q is missing from catalog", id, name)
+		}
+	}
+	if _, ok := LookupDMPaper(0); ok {
+		t.Errorf("LookupDMPaper(0) succeeded")
+	}
+	if id := A4.DMPaper(); id != 9 {
+		t.Errorf("A4.DMPaper() = %d, want 9", id)
+	}
+	if id := Letter.DMPaper(); id != 1 {
+		t.Errorf("Letter.DMPaper() = %d, want 1", id)
+	}
+}
+
+func TestPoints(t *testing.T) {
+	w, h := Letter.Points()
+	if w != 612 || h != 792 {
+		t.Errorf("Letter.Points() = %v, %v, want 612, 792", w, h)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pcl converts images into PCL 5 raster graphics.
+//
+// Raster graphics produced by this package are a fragment of PCL job.
+// They can be embedded between other PCL commands, for example:
+//
+//	w.Write([]byte("\x1bE"))        // reset printer
+//	pcl.WriteImage(w, logo, opts)
+//	w.Write([]byte("\f\x1bE"))      // eject page and reset printer
+package pcl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+// Compression selects raster data compression method.
+type Compression int
+
+const (
+	// CompressAuto uses the method that produces the shortest
+	// data for every row.
+	CompressAuto Compression = iota
+	// CompressNone sends rows unencoded (PCL mode 0).
+	CompressNone
+	// CompressTIFF uses TIFF PackBits encoding (PCL mode 2).
+	CompressTIFF
+	// CompressDeltaRow only sends bytes that differ from
+	// the previous row (PCL mode 3).
+	CompressDeltaRow
+)
+
+// pclMode returns PCL compression mode number of c.
+func (c Compression) pclMode() int {
+	switch c {
+	case CompressTIFF:
+		return 2
+	case CompressDeltaRow:
+		return 3
+	default:
+		return 0
+	}
+}
+
+var ErrResolution = errors.New("pcl: unsupported raster resolution")
+
+// ImageOptions describes how image is printed.
+type ImageOptions struct {
+	// Resolution is raster resolution in dots per inch. One image
+	// pixel is printed as one dot. It must be 75, 100, 150, 200,
+	// 300 or 600. 300, if zero.
+	Resolution int
+	// X and Y is image top left corner position in points (1/72 inch).
+	// X is measured from logical page left edge, and Y from top margin.
+	X, Y        float64
+	Dither      bitmap.Dither
+	Compression Compression
+}
+
+// WriteImage converts image m to black and white and writes it
+// to w as PCL raster graphics. opts can be nil.
+func WriteImage(w io.Writer, m image.Image, opts *ImageOptions) error {
+	if opts == nil {
+		opts = &ImageOptions{}
+	}
+	return WriteBitmap(w, bitmap.FromImage(m, opts.Dither), opts)
+}
+
+// WriteBitmap writes bitmap b to w as PCL raster graphics.
+// opts Dither field is ignored. opts can be nil.
+func WriteBitmap(w io.Writer, b *bitmap.Bitmap, opts *ImageOptions) error {
+	if opts == nil {
+		opts = &ImageOptions{}
+	}
+	res := opts.Resolution
+	switch res {
+	case 0:
+		res = 300
+	case 75, 100, 150, 200, 300, 600:
+	default:
+		return ErrResolution
+	}
+	bw := bufio.NewWriter(w)
+	// Cursor position is in decipoints.
+	fmt.Fprintf(bw, "\x1b&a%dh%dV", int(math.Round(opts.X*10)), int(math.Round(opts.Y*10)))
+	fmt.Fprintf(bw, "\x1b*t%dR", res)
+	fmt.Fprintf(bw, "\x1b*r%ds%dT", b.Width, b.Height)
+	// Start raster graphics at the cursor position.
+	bw.WriteString("\x1b*r1A")
+
+	mode := -1
+	seed := make([]byte, b.Stride)
+	var buf []byte
+	for y := 0; y < b.Height; y++ {
+		row := b.Row(y)
+		var data []byte
+		var m int
+		switch opts.Compression {
+		case CompressNone:
+			data, m = trimZeros(row), 0
+		case CompressTIFF:
+			data, m = packBits(buf[:0], trimZeros(row)), 2
+		case CompressDeltaRow:
+			data, m = deltaRow(buf[:0], row, seed), 3
+		default:
+			data, m = deltaRow(buf[:0], row, seed), 3
+			if d := packBits(nil, trimZeros(row)); len(d) < len(data) {
+				data, m = d, 2
+			}
+		}
+		buf = data
+		if m != mode {
+			fmt.Fprintf(bw, "\x1b*b%dM", m)
+			mode = m
+		}
+		fmt.Fprintf(bw, "\x1b*b%dW", len(data))
+		bw.Write(data)
+		copy(seed, row)
+	}
+	bw.WriteString("\x1b*rC")
+	return bw.Flush()
+}
+
+// trimZeros returns row without trailing zero bytes. Printer
+// fills missing bytes of mode 0 and mode 2 rows with zeros.
+func trimZeros(row []byte) []byte {
+	n := len(row)
+	for n > 0 && row[n-1] == 0 {
+		n--
+	}
+	return row[:n]
+}
+
+// packBits appends row encoded with TIFF PackBits to dst.
+func packBits(dst, row []byte) []byte {
+	for i := 0; i < len(row); {
+		// Count repeated bytes.
+		n := 1
+		for i+n < len(row) && n < 128 && row[i+n] == row[i] {
+			n++
+		}
+		if n > 1 {
+			dst = append(dst, byte(1-n), row[i])
+			i += n
+			continue
+		}
+		// Collect literal bytes until next run of at least 3.
+		j := i + 1
+		for j < len(row) && j-i < 128 {
+			if j+2 < len(row) && row[j] == row[j+1] && row[j] == row[j+2] {
+				break
+			}
+			j++
+		}
+		dst = append(dst, byte(j-i-1))
+		dst = append(dst, row[i:j]...)
+		i = j
+	}
+	return dst
+}
+
+// deltaRow appends row encoded relative to seed row with PCL delta
+// row compression to dst. seed and row must have the same length.
+func deltaRow(dst, row, seed []byte) []byte {
+	last := 0 // position after last replaced byte
+	for i := 0; i < len(row); {
+		if row[i] == seed[i] {
+			i++
+			continue
+		}
+		// Up to 8 bytes can be replaced with single command.
+		n := 1
+		for i+n < len(row) && n < 8 && row[i+n] != seed[i+n] {
+			n++
+		}
+		off := i - last
+		if off < 31 {
+			dst = append(dst, byte((n-1)<<5|off))
+		} else {
+			dst = append(dst, byte((n-1)<<5|31))
+			off -= 31
+			for off >= 255 {
+				dst = append(dst, 255)
+				off -= 255
+			}
+			dst = append(dst, byte(off))
+		}
+		dst = append(dst, row[i:i+n]...)
+		i += n
+		last = i
+	}
+	return dst
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pcl
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+func unpackBits(data []byte) []byte {
+	var out []byte
+	for i := 0; i < len(data); {
+		n := int(int8(data[i]))
+		i++
+		switch {
+		case n >= 0:
+			out = append(out, data[i:i+n+1]...)
+			i += n + 1
+		case n > -128:
+			out = append(out, bytes.Repeat(data[i:i+1], 1-n)...)
+			i++
+		}
+	}
+	return out
+}
+
+func undeltaRow(data, seed []byte) []byte {
+	row := append([]byte(nil), seed...)
+	pos := 0
+	for i := 0; i < len(data); {
+		n := int(data[i]>>5) + 1
+		off := int(data[i] & 31)
+		i++
+		if off == 31 {
+			for {
+				off += int(data[i])
+				i++
+				if data[i-1] != 255 {
+					break
+				}
+			}
+		}
+		pos += off
+		copy(row[pos:], data[i:i+n])
+		i += n
+		pos += n
+	}
+	return row
+}
+
+var rasterCmd = regexp.MustCompile(`^\x1b\*b(\d+)([MW])`)
+
+// decodeRaster decodes PCL raster rows of width bytes from data.
+func decodeRaster(t *testing.T, data []byte, width int) [][]byte {
+	t.Helper()
+	start := bytes.Index(data, []byte("\x1b*r1A"))
+	end := bytes.LastIndex(data, []byte("\x1b*rC"))
+	if start < 0 || end != len(data)-4 {
+		t.Fatalf("raster graphics start or end is missing: %q", data)
+	}
+	data = data[start+5 : end]
+	var rows [][]byte
+	seed := make([]byte, width)
+	mode := 0
+	for len(data) > 0 {
+		m := rasterCmd.FindSubmatch(data)
+		if m == nil {
+			t.Fatalf("unexpected raster data %q", data)
+		}
+		data = data[len(m[0]):]
+		v, _ := strconv.Atoi(string(m[1]))
+		if m[2][0] == 'M' {
+			mode = v
+			continue
+		}
+		b := data[:v]
+		data = data[v:]
+		var row []byte
+		switch mode {
+		case 0:
+			row = append([]byte(nil), b...)
+		case 2:
+			row = unpackBits(b)
+		case 3:
+			row = undeltaRow(b, seed)
+		default:
+			t.Fatalf("unexpected compression mode %d", mode)
+		}
+		if len(row) > width {
+			t.Fatalf("row is %d bytes long, want at most %d", len(row), width)
+		}
+		row = append(row, make([]byte, width-len(row))...)
+		rows = append(rows, row)
+		seed = row
+	}
+	return rows
+}
+
+func TestPackBits(t *testing.T) {
+	rand.Seed(1)
+	tests := [][]byte{
+		{},
+		{1},
+		{1, 1},
+		{1, 2, 3, 3, 3, 3, 4, 5},
+		bytes.Repeat([]byte{7}, 300),
+	}
+	for i := 0; i < 20; i++ {
+		b := make([]byte, rand.Intn(400))
+		for j := range b {
+			b[j] = byte(rand.Intn(3))
+		}
+		tests = append(tests, b)
+	}
+	for _, row := range tests {
+		got := unpackBits(packBits(nil, row))
+		if !bytes.Equal(got, row) {
+			t.Fatalf("packBits round trip of %v returned %v", row, got)
+		}
+	}
+	if got, want := packBits(nil, []byte{1, 2, 3, 3, 3, 3, 4, 5}), []byte{1, 1, 2, 0xfd, 3, 1, 4, 5}; !bytes.Equal(got, want) {
+		t.Errorf("packBits returned %v, want %v", got, want)
+	}
+}
+
+func TestDeltaRow(t *testing.T) {
+	rand.Seed(2)
+	for i := 0; i < 50; i++ {
+		seed := make([]byte, 600)
+		rand.Read(seed)
+		row := append([]byte(nil), seed...)
+		for j := rand.Intn(20); j > 0; j-- {
+			row[rand.Intn(len(row))] ^= 0xff
+		}
+		got := undeltaRow(deltaRow(nil, row, seed), seed)
+		if !bytes.Equal(got, row) {
+			t.Fatal("deltaRow round trip failed")
+		}
+	}
+	seed := make([]byte, 300)
+	row := make([]byte, 300)
+	row[1], row[2], row[290] = 1, 2, 3
+	want := []byte{0x21, 1, 2, 31, 255, 1, 3}
+	if got := deltaRow(nil, row, seed); !bytes.Equal(got, want) {
+		t.Errorf("deltaRow returned %v, want %v", got, want)
+	}
+}
+
+func TestWriteBitmap(t *testing.T) {
+	b := bitmap.New(20, 3)
+	b.Set(0, 0, true)
+	b.Set(19, 2, true)
+	var buf bytes.Buffer
+	err := WriteBitmap(&buf, b, &ImageOptions{X: 72, Y: 36.05, Resolution: 150, Compression: CompressNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b&a720h361V\x1b*t150R\x1b*r20s3T\x1b*r1A" +
+		"\x1b*b0M\x1b*b1W\x80\x1b*b0W\x1b*b3W\x00\x00\x10\x1b*rC"
+	if buf.String() != want {
+		t.Errorf("WriteBitmap returned %q, want %q", buf.String(), want)
+	}
+	if err := WriteBitmap(&buf, b, &ImageOptions{Resolution: 123}); err != ErrResolution {
+		t.Errorf("WriteBitmap returned %v, want %v", err, ErrResolution)
+	}
+}
+
+func TestWriteImage(t *testing.T) {
+	m := image.NewGray(image.Rect(0, 0, 203, 61))
+	for y := 0; y < 61; y++ {
+		for x := 0; x < 203; x++ {
+			m.SetGray(x, y, color.Gray{Y: uint8(x + y)})
+		}
+	}
+	b := bitmap.FromImage(m, bitmap.Ordered)
+	for _, c := range []Compression{CompressAuto, CompressNone, CompressTIFF, CompressDeltaRow} {
+		var buf bytes.Buffer
+		err := WriteImage(&buf, m, &ImageOptions{Dither: bitmap.Ordered, Compression: c})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := decodeRaster(t, buf.Bytes(), b.Stride)
+		if len(rows) != b.Height {
+			t.Fatalf("compression %d: got %d rows, want %d", c, len(rows), b.Height)
+		}
+		for y, row := range rows {
+			if !bytes.Equal(row, b.Row(y)) {
+				t.Fatalf("compression %d: row %d is %x, want %x", c, y, row, b.Row(y))
+			}
+		}
+	}
+}
@@ -0,0 +1,591 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pdf implements minimal PDF writer. It is meant for generating
+// simple printable documents, like reports and labels, that consist of
+// text in standard fonts, lines, rectangles and images.
+//
+// All coordinates are in points (1/72 inch) with origin in the bottom
+// left corner of the page.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Font is one of the standard 14 PDF fonts. Standard fonts are
+// available in every PDF reader, so they are not embedded in the document.
+type Font string
+
+const (
+	Helvetica            Font = "Helvetica"
+	HelveticaBold        Font = "Helvetica-Bold"
+	HelveticaOblique     Font = "Helvetica-Oblique"
+	HelveticaBoldOblique Font = "Helvetica-BoldOblique"
+	TimesRoman           Font = "Times-Roman"
+	TimesBold            Font = "Times-Bold"
+	TimesItalic          Font = "Times-Italic"
+	TimesBoldItalic      Font = "Times-BoldItalic"
+	Courier              Font = "Courier"
+	CourierBold          Font = "Courier-Bold"
+	CourierOblique       Font = "Courier-Oblique"
+	CourierBoldOblique   Font = "Courier-BoldOblique"
+	Symbol               Font = "Symbol"
+	ZapfDingbats         Font = "ZapfDingbats"
+)
+
+var (
+	ErrClosed       = errors.New("pdf: writer is closed")
+	ErrNoPage       = errors.New("pdf: document has no pages")
+	ErrNoFont       = errors.New("pdf: font is not set")
+	ErrBadJPEG      = errors.New("pdf: invalid JPEG data")
+	ErrForeignImage = errors.New("pdf: image belongs to another writer")
+)
+
+// countWriter counts bytes written to w and remembers the first error.
+type countWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countWriter) Write(b []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
+
+// Writer writes PDF document. Pages are added with NewPage, and the
+// document must be finished with Close.
+type Writer struct {
+	// Title and Creator are stored in the document information
+	// dictionary, if not empty. They must be set before Close.
+	Title   string
+	Creator string
+
+	w       *countWriter
+	offsets []int64 // object offsets; object number is index + 1
+	pages   []int   // page object numbers
+	fonts   map[Font]*fontRef
+	page    *Page // current page
+	images  int   // number of images
+	closed  bool
+}
+
+type fontRef struct {
+	name     string // resource name
+	obj      int
+	symbolic bool // font uses its built-in encoding
+}
+
+// Object numbers of the document catalog and the page tree root.
+const (
+	catalogObj = 1
+	pagesObj   = 2
+)
+
+// NewWriter returns new Writer that writes PDF document to w.
+func NewWriter(w io.Writer) *Writer {
+	pw := &Writer{
+		w:       &countWriter{w: w},
+		offsets: make([]int64, 2),
+		fonts:   make(map[Font]*fontRef),
+	}
+	// Binary comment marks the file as binary for transfer programs.
+	io.WriteString(pw.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	return pw
+}
+
+// newObj allocates new object number.
+func (w *Writer) newObj() int {
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets)
+}
+
+// writeObj writes object number obj with content body.
+func (w *Writer) writeObj(obj int, body string) {
+	w.offsets[obj-1] = w.w.n
+	fmt.Fprintf(w.w, "%d 0 obj\n%s\nendobj\n", obj, body)
+}
+
+// writeStream writes stream object number obj. dict is stream
+// dictionary without Length entry.
+func (w *Writer) writeStream(obj int, dict string, data []byte) {
+	w.offsets[obj-1] = w.w.n
+	fmt.Fprintf(w.w, "%d 0 obj\n<< %s/Length %d >>\nstream\n", obj, dict, len(data))
+	w.w.Write(data)
+	io.WriteString(w.w, "\nendstream\nendobj\n")
+}
+
+// font returns resource for font f, writing font object if needed.
+func (w *Writer) font(f Font) *fontRef {
+	if r, ok := w.fonts[f]; ok {
+		return r
+	}
+	r := &fontRef{name: "F" + strconv.Itoa(len(w.fonts)+1), obj: w.newObj()}
+	enc := "/Encoding /WinAnsiEncoding "
+	if f == Symbol || f == ZapfDingbats {
+		// Symbolic fonts use their built-in encoding.
+		enc = ""
+		r.symbolic = true
+	}
+	w.writeObj(r.obj, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s %s>>", f, enc))
+	w.fonts[f] = r
+	return r
+}
+
+// NewPage finishes current page, if any, and starts new page
+// of the given size.
+func (w *Writer) NewPage(width, height float64) (*Page, error) {
+	if w.closed {
+		return nil, ErrClosed
+	}
+	w.endPage()
+	if w.w.err != nil {
+		return nil, w.w.err
+	}
+	w.page = &Page{
+		w:      w,
+		width:  width,
+		height: height,
+		fonts:  make(map[string]int),
+		images: make(map[string]int),
+	}
+	return w.page, nil
+}
+
+// endPage writes current page to the document.
+func (w *Writer) endPage() {
+	p := w.page
+	if p == nil {
+		return
+	}
+	w.page = nil
+	content := w.newObj()
+	w.writeStream(content, "", p.content.Bytes())
+	var res strings.Builder
+	if len(p.fonts) > 0 {
+		res.WriteString("/Font <<")
+		for _, name := range sortedKeys(p.fonts) {
+			fmt.Fprintf(&res, " /%s %d 0 R", name, p.fonts[name])
+		}
+		res.WriteString(" >> ")
+	}
+	if len(p.images) > 0 {
+		res.WriteString("/XObject <<")
+		for _, name := range sortedKeys(p.images) {
+			fmt.Fprintf(&res, " /%s %d 0 R", name, p.images[name])
+		}
+		res.WriteString(" >> ")
+	}
+	obj := w.newObj()
+	w.writeObj(obj, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << %s>> /Contents %d 0 R >>",
+		pagesObj, num(p.width), num(p.height), res.String(), content))
+	w.pages = append(w.pages, obj)
+}
+
+// Close finishes the document. It does not close underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+	w.endPage()
+	if len(w.pages) == 0 {
+		return ErrNoPage
+	}
+	kids := make([]string, len(w.pages))
+	for i, obj := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", obj)
+	}
+	w.writeObj(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	w.writeObj(catalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	info := 0
+	if w.Title != "" || w.Creator != "" {
+		var b strings.Builder
+		b.WriteString("<<")
+		if w.Title != "" {
+			fmt.Fprintf(&b, " /Title %s", textString(w.Title))
+		}
+		if w.Creator != "" {
+			fmt.Fprintf(&b, " /Creator %s", textString(w.Creator))
+		}
+		b.WriteString(" >>")
+		info = w.newObj()
+		w.writeObj(info, b.String())
+	}
+
+	xref := w.w.n
+	fmt.Fprintf(w.w, "xref\n0 %d\n", len(w.offsets)+1)
+	// Every cross-reference entry is exactly 20 bytes long.
+	io.WriteString(w.w, "0000000000 65535 f\r\n")
+	for _, off := range w.offsets {
+		fmt.Fprintf(w.w, "%010d 00000 n\r\n", off)
+	}
+	fmt.Fprintf(w.w, "trailer\n<< /Size %d /Root %d 0 R", len(w.offsets)+1, catalogObj)
+	if info != 0 {
+		fmt.Fprintf(w.w, " /Info %d 0 R", info)
+	}
+	fmt.Fprintf(w.w, " >>\nstartxref\n%d\n%%%%EOF\n", xref)
+	return w.w.err
+}
+
+// Image is an image stored in the document. The same image can be
+// drawn many times on any page of the document.
+type Image struct {
+	w             *Writer
+	name          string // resource name
+	obj           int
+	Width, Height int // in pixels
+}
+
+func (w *Writer) newImage(width, height int) *Image {
+	w.images++
+	return &Image{
+		w:      w,
+		name:   "Im" + strconv.Itoa(w.images),
+		obj:    w.newObj(),
+		Width:  width,
+		Height: height,
+	}
+}
+
+// AddImage stores image m in the document. Images with gray color
+// model are stored as DeviceGray, and all others as DeviceRGB.
+// Transparent pixels are composed over white background. Image data
+// is Flate compressed.
+func (w *Writer) AddImage(m image.Image) (*Image, error) {
+	if w.closed {
+		return nil, ErrClosed
+	}
+	b := m.Bounds()
+	gray := m.ColorModel() == color.GrayModel || m.ColorModel() == color.Gray16Model
+	cs, ncomp := "/DeviceRGB", 3
+	if gray {
+		cs, ncomp = "/DeviceGray", 1
+	}
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	row := make([]byte, b.Dx()*ncomp)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		i := 0
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := m.At(x, y).RGBA()
+			// Colors are alpha-premultiplied, so adding
+			// missing alpha composes them over white.
+			r += 0xffff - a
+			g += 0xffff - a
+			bl += 0xffff - a
+			if gray {
+				row[i] = byte(r >> 8)
+				i++
+				continue
+			}
+			row[i], row[i+1], row[i+2] = byte(r>>8), byte(g>>8), byte(bl>>8)
+			i += 3
+		}
+		zw.Write(row)
+	}
+	zw.Close()
+	img := w.newImage(b.Dx(), b.Dy())
+	w.writeStream(img.obj, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /FlateDecode ",
+		img.Width, img.Height, cs), buf.Bytes())
+	return img, w.w.err
+}
+
+// AddJPEG stores JPEG image data in the document as is. Grayscale,
+// RGB and CMYK JPEG images are supported.
+func (w *Writer) AddJPEG(data []byte) (*Image, error) {
+	if w.closed {
+		return nil, ErrClosed
+	}
+	width, height, ncomp, adobe, err := jpegInfo(data)
+	if err != nil {
+		return nil, err
+	}
+	var cs string
+	switch ncomp {
+	case 1:
+		cs = "/DeviceGray"
+	case 3:
+		cs = "/DeviceRGB"
+	case 4:
+		cs = "/DeviceCMYK"
+		if adobe {
+			// Adobe applications write CMYK JPEG images inverted,
+			// and mark them with APP14 segment.
+			cs += " /Decode [1 0 1 0 1 0 1 0]"
+		}
+	default:
+		return nil, ErrBadJPEG
+	}
+	img := w.newImage(width, height)
+	w.writeStream(img.obj, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode ",
+		width, height, cs), data)
+	return img, w.w.err
+}
+
+// jpegInfo returns JPEG image size and number of color components
+// found in the image start of frame segment. adobe reports, if the
+// image has Adobe APP14 segment before the frame.
+func jpegInfo(data []byte) (width, height, ncomp int, adobe bool, err error) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return 0, 0, 0, false, ErrBadJPEG
+	}
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xff {
+			return 0, 0, 0, false, ErrBadJPEG
+		}
+		marker := data[i+1]
+		if marker == 0xff {
+			// Fill byte.
+			i++
+			continue
+		}
+		if marker == 0xd8 || marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7) {
+			// Markers without segment.
+			i += 2
+			continue
+		}
+		n := int(data[i+2])<<8 | int(data[i+3])
+		if n < 2 || i+2+n > len(data) {
+			return 0, 0, 0, false, ErrBadJPEG
+		}
+		switch marker {
+		case 0xc0, 0xc1, 0xc2, 0xc3, 0xc5, 0xc6, 0xc7, 0xc9, 0xca, 0xcb, 0xcd, 0xce, 0xcf:
+			if n < 8 {
+				return 0, 0, 0, false, ErrBadJPEG
+			}
+			seg := data[i+4:]
+			height = int(seg[1])<<8 | int(seg[2])
+			width = int(seg[3])<<8 | int(seg[4])
+			ncomp = int(seg[5])
+			if width == 0 || height == 0 {
+				return 0, 0, 0, false, ErrBadJPEG
+			}
+			return width, height, ncomp, adobe, nil
+		case 0xee:
+			if n >= 7 && string(data[i+4:i+9]) == "Adobe" {
+				adobe = true
+			}
+		case 0xd9, 0xda:
+			// End of image or start of scan before frame header.
+			return 0, 0, 0, false, ErrBadJPEG
+		}
+		i += 2 + n
+	}
+	return 0, 0, 0, false, ErrBadJPEG
+}
+
+// Page is a document page returned by NewPage. Page content is
+// written to the document, when next page is started or when
+// the document is closed.
+type Page struct {
+	w             *Writer
+	width, height float64
+	content       bytes.Buffer
+	font          *fontRef
+	fontSize      float64
+	fonts         map[string]int // font resources used by the page
+	images        map[string]int // image resources used by the page
+}
+
+// Size returns page width and height.
+func (p *Page) Size() (width, height float64) {
+	return p.width, p.height
+}
+
+// SetFont sets font used by Text.
+func (p *Page) SetFont(f Font, size float64) {
+	p.font = p.w.font(f)
+	p.fontSize = size
+	p.fonts[p.font.name] = p.font.obj
+}
+
+// Text draws string s with baseline starting at x, y. s is converted to
+// WinAnsi encoding, and characters that do not fit are replaced with '?'.
+// Symbol and ZapfDingbats fonts have their own encoding, so bytes of s
+// are used as character codes of these fonts as is, for example "\xa5"
+// is infinity sign in Symbol font. Text color is set with SetFillColor.
+func (p *Page) Text(x, y float64, s string) error {
+	if p.font == nil {
+		return ErrNoFont
+	}
+	b := []byte(s)
+	if !p.font.symbolic {
+		b = winAnsi(s)
+	}
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td %s Tj ET\n",
+		p.font.name, num(p.fontSize), num(x), num(y), pdfString(b))
+	return nil
+}
+
+// SetLineWidth sets width of lines drawn by Line and StrokeRect.
+func (p *Page) SetLineWidth(width float64) {
+	fmt.Fprintf(&p.content, "%s w\n", num(width))
+}
+
+func colorOps(c color.Color) string {
+	if g, ok := c.(color.Gray); ok {
+		return num(float64(g.Y)/255) + " "
+	}
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("%s %s %s ", num(float64(r)/0xffff), num(float64(g)/0xffff), num(float64(b)/0xffff))
+}
+
+// SetStrokeColor sets color of lines drawn by Line and StrokeRect.
+func (p *Page) SetStrokeColor(c color.Color) {
+	if _, ok := c.(color.Gray); ok {
+		fmt.Fprintf(&p.content, "%sG\n", colorOps(c))
+		return
+	}
+	fmt.Fprintf(&p.content, "%sRG\n", colorOps(c))
+}
+
+// SetFillColor sets color used by FillRect and Text.
+func (p *Page) SetFillColor(c color.Color) {
+	if _, ok := c.(color.Gray); ok {
+		fmt.Fprintf(&p.content, "%sg\n", colorOps(c))
+		return
+	}
+	fmt.Fprintf(&p.content, "%srg\n", colorOps(c))
+}
+
+// Line draws line from x1, y1 to x2, y2.
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "%s %s m %s %s l S\n", num(x1), num(y1), num(x2), num(y2))
+}
+
+// StrokeRect draws outline of rectangle with bottom left
+// corner at x, y.
+func (p *Page) StrokeRect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re S\n", num(x), num(y), num(width), num(height))
+}
+
+// FillRect fills rectangle with bottom left corner at x, y.
+func (p *Page) FillRect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(y), num(width), num(height))
+}
+
+// DrawImage draws image img scaled into rectangle with bottom
+// left corner at x, y.
+func (p *Page) DrawImage(img *Image, x, y, width, height float64) error {
+	if img.w != p.w {
+		return ErrForeignImage
+	}
+	p.images[img.name] = img.obj
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /%s Do Q\n", num(width), num(height), num(x), num(y), img.name)
+	return nil
+}
+
+// winAnsiHigh maps characters to WinAnsiEncoding codes 0x80 - 0x9f.
+var winAnsiHigh = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// winAnsi returns s converted to WinAnsiEncoding.
+func winAnsi(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			b = append(b, byte(r))
+		case winAnsiHigh[r] != 0:
+			b = append(b, winAnsiHigh[r])
+		default:
+			b = append(b, '?')
+		}
+	}
+	return b
+}
+
+// pdfString returns b as PDF literal string.
+func pdfString(b []byte) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for _, c := range b {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// textString returns s as PDF text string. ASCII strings are
+// written as is, and all others in UTF-16BE with byte order mark.
+func textString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r >= 0x80 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return pdfString([]byte(s))
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, r := range s {
+		if r >= 0x10000 {
+			r -= 0x10000
+			fmt.Fprintf(&b, "%04X%04X", 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+			continue
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+// sortedKeys returns resource names of m in order they were
+// allocated (F1, F2, ..., F10).
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// num formats v with at most 2 decimal places.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// checkXref verifies that cross-reference table of PDF document
+// doc points at objects, and returns number of objects.
+func checkXref(t *testing.T, doc []byte) int {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(doc)
+	if m == nil {
+		t.Fatal("startxref is not found")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(doc[xref:], []byte("xref\n0 ")) {
+		t.Fatalf("startxref %d does not point at xref table", xref)
+	}
+	lines := strings.SplitN(string(doc[xref:]), "\n", 3)
+	n, _ := strconv.Atoi(strings.Fields(lines[1])[1])
+	entries := lines[2]
+	for i := 0; i < n; i++ {
+		e := entries[i*20 : (i+1)*20]
+		if i == 0 {
+			if e != "0000000000 65535 f\r\n" {
+				t.Fatalf("bad first xref entry %q", e)
+			}
+			continue
+		}
+		off, err := strconv.Atoi(e[:10])
+		if err != nil || e[10:] != " 00000 n\r\n" {
+			t.Fatalf("bad xref entry %d: %q", i, e)
+		}
+		want := fmt.Sprintf("%d 0 obj\n", i)
+		if !bytes.HasPrefix(doc[off:], []byte(want)) {
+			t.Fatalf("xref entry %d does not point at object %d", i, i)
+		}
+	}
+	if !strings.HasPrefix(entries[n*20:], fmt.Sprintf("trailer\n<< /Size %d ", n)) {
+		t.Fatalf("bad trailer: %q", entries[n*20:])
+	}
+	return n - 1
+}
+
+// object returns content of object number obj in doc.
+func object(doc []byte, obj int) string {
+	s := string(doc)
+	start := strings.Index(s, fmt.Sprintf("\n%d 0 obj\n", obj))
+	if start < 0 {
+		return ""
+	}
+	s = s[start+1:]
+	return s[:strings.Index(s, "endobj\n")]
+}
+
+// streamData returns stream data of object s.
+func streamData(s string) []byte {
+	start := strings.Index(s, "stream\n") + len("stream\n")
+	end := strings.LastIndex(s, "\nendstream")
+	return []byte(s[start:end])
+}
+
+func TestWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b)
+	w.Title = "Report – May"
+	w.Creator = "test"
+	p, err := w.NewPage(595.28, 841.89)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Text(10, 10, "x"); err != ErrNoFont {
+		t.Fatalf("Text without font returned %v, want %v", err, ErrNoFont)
+	}
+	p.SetFont(Helvetica, 12)
+	p.SetFillColor(color.Gray{Y: 128})
+	p.Text(72, 700, "Total: 5 € (net)")
+	p.SetLineWidth(0.5)
+	p.SetStrokeColor(color.RGBA{R: 255, A: 255})
+	p.Line(72, 690, 523.28, 690)
+	p.StrokeRect(72, 600, 100, 50)
+	p.FillRect(200, 600, 100, 50)
+	p.SetFont(CourierBold, 10)
+	p.Text(72, 580, `a\b`)
+	p.SetFont(Helvetica, 8)
+
+	p, err = w.NewPage(612, 792)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SetFont(Symbol, 10)
+	p.Text(0, 0, "abc\xa5")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != ErrClosed {
+		t.Fatalf("second Close returned %v, want %v", err, ErrClosed)
+	}
+
+	doc := b.Bytes()
+	if !bytes.HasPrefix(doc, []byte("%PDF-1.4\n")) {
+		t.Fatal("PDF header is missing")
+	}
+	n := checkXref(t, doc)
+	if n != 10 {
+		t.Errorf("document has %d objects, want 10", n)
+	}
+	want := []string{
+		"1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\n",
+		"2 0 obj\n<< /Type /Pages /Kids [6 0 R 9 0 R] /Count 2 >>\n",
+		"3 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\n",
+		"4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>\n",
+		"5 0 obj\n<< /Length 173 >>\nstream\n" +
+			"0.5 g\n" +
+			"BT /F1 12 Tf 72 700 Td (Total: 5 \\200 \\(net\\)) Tj ET\n" +
+			"0.5 w\n" +
+			"1 0 0 RG\n" +
+			"72 690 m 523.28 690 l S\n" +
+			"72 600 100 50 re S\n" +
+			"200 600 100 50 re f\n" +
+			"BT /F2 10 Tf 72 580 Td (a\\\\b) Tj ET\n" +
+			"\nendstream\n",
+		"6 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595.28 841.89] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents 5 0 R >>\n",
+		"7 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Symbol >>\n",
+		"BT /F3 10 Tf 0 0 Td (abc\\245) Tj ET\n",
+		"9 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F3 7 0 R >> >> /Contents 8 0 R >>\n",
+		"10 0 obj\n<< /Title <FEFF005200650070006F00720074002020130020004D00610079> /Creator (test) >>\n",
+	}
+	for _, s := range want {
+		if !bytes.Contains(doc, []byte(s)) {
+			t.Errorf("document does not contain %q", s)
+		}
+	}
+}
+
+func TestNoPages(t *testing.T) {
+	w := NewWriter(ioutil.Discard)
+	if err := w.Close(); err != ErrNoPage {
+		t.Fatalf("Close returned %v, want %v", err, ErrNoPage)
+	}
+	if _, err := w.NewPage(10, 10); err != ErrClosed {
+		t.Fatalf("NewPage returned %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestAddImage(t *testing.T) {
+	rgba := image.NewNRGBA(image.Rect(1, 1, 3, 2))
+	rgba.Set(1, 1, color.NRGBA{R: 255, G: 0, B: 0, A: 255})
+	rgba.Set(2, 1, color.NRGBA{R: 0, G: 0, B: 0, A: 0})
+	gray := image.NewGray(image.Rect(0, 0, 3, 1))
+	gray.Pix = []byte{0, 128, 255}
+
+	var b bytes.Buffer
+	w := NewWriter(&b)
+	im1, err := w.AddImage(rgba)
+	if err != nil {
+		t.Fatal(err)
+	}
+	im2, err := w.AddImage(gray)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := w.NewPage(100, 100)
+	p.DrawImage(im2, 10, 20, 30, 10)
+	p.DrawImage(im1, 0, 0, 20, 10)
+	if err := p.DrawImage(&Image{}, 0, 0, 1, 1); err != ErrForeignImage {
+		t.Fatalf("DrawImage returned %v, want %v", err, ErrForeignImage)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	doc := b.Bytes()
+	checkXref(t, doc)
+
+	tests := []struct {
+		obj  int
+		dict string
+		pix  []byte
+	}{
+		{3, "/Width 2 /Height 1 /ColorSpace /DeviceRGB", []byte{255, 0, 0, 255, 255, 255}},
+		{4, "/Width 3 /Height 1 /ColorSpace /DeviceGray", []byte{0, 128, 255}},
+	}
+	for _, test := range tests {
+		s := object(doc, test.obj)
+		if !strings.Contains(s, test.dict) || !strings.Contains(s, "/Filter /FlateDecode") {
+			t.Errorf("unexpected image %d dictionary: %q", test.obj, s[:strings.Index(s, "stream")])
+			continue
+		}
+		r, err := zlib.NewReader(bytes.NewReader(streamData(s)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pix, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pix, test.pix) {
+			t.Errorf("image %d has pixels %v, want %v", test.obj, pix, test.pix)
+		}
+	}
+	content := string(streamData(object(doc, 5)))
+	want := "q 30 0 0 10 10 20 cm /Im2 Do Q\nq 20 0 0 10 0 0 cm /Im1 Do Q\n"
+	if content != want {
+		t.Errorf("unexpected page content %q, want %q", content, want)
+	}
+	if s := object(doc, 6); !strings.Contains(s, "/XObject << /Im1 3 0 R /Im2 4 0 R >>") {
+		t.Errorf("unexpected page resources: %q", s)
+	}
+}
+
+func TestAddJPEG(t *testing.T) {
+	var data bytes.Buffer
+	m := image.NewRGBA(image.Rect(0, 0, 17, 9))
+	if err := jpeg.Encode(&data, m, nil); err != nil {
+		t.Fatal(err)
+	}
+	var gdata bytes.Buffer
+	if err := jpeg.Encode(&gdata, image.NewGray(image.Rect(0, 0, 4, 5)), nil); err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	w := NewWriter(&b)
+	img, err := w.AddJPEG(data.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width != 17 || img.Height != 9 {
+		t.Errorf("JPEG image size is %dx%d, want 17x9", img.Width, img.Height)
+	}
+	if _, err := w.AddJPEG(gdata.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddJPEG([]byte("not a jpeg")); err != ErrBadJPEG {
+		t.Fatalf("AddJPEG returned %v, want %v", err, ErrBadJPEG)
+	}
+	if _, err := w.AddJPEG(data.Bytes()[:20]); err != ErrBadJPEG {
+		t.Fatalf("AddJPEG of truncated data returned %v, want %v", err, ErrBadJPEG)
+	}
+	p, _ := w.NewPage(100, 100)
+	p.DrawImage(img, 0, 0, 100, 100)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	doc := b.Bytes()
+	checkXref(t, doc)
+	s := object(doc, 3)
+	if !strings.Contains(s, "/Width 17 /Height 9 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode") {
+		t.Errorf("unexpected JPEG image dictionary: %q", s[:strings.Index(s, "stream")])
+	}
+	if !bytes.Equal(streamData(s), data.Bytes()) {
+		t.Error("JPEG data is not stored as is")
+	}
+	if s := object(doc, 4); !strings.Contains(s, "/Width 4 /Height 5 /ColorSpace /DeviceGray") {
+		t.Errorf("unexpected gray JPEG image dictionary: %q", s[:strings.Index(s, "stream")])
+	}
+}
+
+// cmykJPEG returns headers of 2x3 CMYK JPEG image, with Adobe APP14
+// segment, if adobe is set.
+func cmykJPEG(adobe bool) []byte {
+	data := []byte("\xff\xd8")
+	if adobe {
+		data = append(data, "\xff\xee\x00\x0eAdobe\x00\x64\x00\x00\x00\x00\x02"...)
+	}
+	data = append(data, "\xff\xc0\x00\x14\x08\x00\x03\x00\x02\x04"+
+		"\x01\x11\x00\x02\x11\x00\x03\x11\x00\x04\x11\x00\xff\xd9"...)
+	return data
+}
+
+func TestAddCMYKJPEG(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b)
+	for _, adobe := range []bool{true, false} {
+		if _, err := w.AddJPEG(cmykJPEG(adobe)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.NewPage(100, 100)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	doc := b.Bytes()
+	if s := object(doc, 3); !strings.Contains(s, "/ColorSpace /DeviceCMYK /Decode [1 0 1 0 1 0 1 0] /BitsPerComponent") {
+		t.Errorf("Adobe CMYK JPEG is not inverted: %q", s[:strings.Index(s, "stream")])
+	}
+	if s := object(doc, 4); !strings.Contains(s, "/ColorSpace /DeviceCMYK /BitsPerComponent") {
+		t.Errorf("CMYK JPEG without APP14 segment is inverted: %q", s[:strings.Index(s, "stream")])
+	}
+}
+
+func TestWinAnsi(t *testing.T) {
+	got := winAnsi("aé€“”—中\n")
+	want := []byte{'a', 0xe9, 0x80, 0x93, 0x94, 0x97, '?', '?'}
+	if !bytes.Equal(got, want) {
+		t.Errorf("winAnsi returned %v, want %v", got, want)
+	}
+}
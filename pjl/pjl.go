@@ -0,0 +1,201 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pjl wraps raw print jobs in HP Printer Job Language (PJL)
+// commands, so job options, like number of copies and duplex, can be
+// set for PCL and PostScript jobs sent directly to the printer.
+package pjl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexbrainman/printer/ticket"
+)
+
+// Language is printer language of the job.
+type Language string
+
+const (
+	PCL        Language = "PCL"
+	PCLXL      Language = "PCLXL"
+	PostScript Language = "POSTSCRIPT"
+	PDF        Language = "PDF"
+)
+
+// UEL is Universal Exit Language command that starts and ends PJL jobs.
+const UEL = "\x1b%-12345X"
+
+var ErrClosed = errors.New("pjl: writer is closed")
+
+// papers maps PWG media names to PJL PAPER values.
+var papers = map[string]string{
+	"iso_a3_297x420mm":          "A3",
+	"iso_a4_210x297mm":          "A4",
+	"iso_a5_148x210mm":          "A5",
+	"iso_a6_105x148mm":          "A6",
+	"jis_b4_257x364mm":          "B4",
+	"jis_b5_182x257mm":          "B5",
+	"iso_b5_176x250mm":          "B5ENV",
+	"iso_c5_162x229mm":          "C5",
+	"iso_dl_110x220mm":          "DL",
+	"na_letter_8.5x11in":        "LETTER",
+	"na_legal_8.5x14in":         "LEGAL",
+	"na_ledger_11x17in":         "LEDGER",
+	"na_executive_7.25x10.5in":  "EXECUTIVE",
+	"na_invoice_5.5x8.5in":      "STATEMENT",
+	"na_number-10_4.125x9.5in":  "COM10",
+	"na_monarch_3.875x7.5in":    "MONARCH",
+	"jpn_hagaki_100x148mm":      "JPOST",
+	"na_foolscap_8.5x13in":      "FOLIO",
+	"na_number-9_3.875x8.875in": "COM9",
+}
+
+// trays maps PWG media-source keywords to PJL MEDIASOURCE values.
+var trays = map[string]string{
+	"tray-1":       "TRAY1",
+	"tray-2":       "TRAY2",
+	"tray-3":       "TRAY3",
+	"tray-4":       "TRAY4",
+	"tray-5":       "TRAY5",
+	"by-pass-tray": "TRAY1",
+	"manual":       "MANUALFEED",
+	"envelope":     "ENVELOPE",
+}
+
+// Writer writes print job wrapped in PJL job. PJL header is written
+// before the first data written, and the job is finished by Close.
+type Writer struct {
+	w      io.Writer
+	lang   Language
+	t      ticket.Ticket
+	header bool // header is written
+	closed bool
+}
+
+// NewWriter returns new Writer that writes job of language lang with
+// options t to w. w can be any io.Writer, like *printer.Printer.
+// t can be nil. Options without PJL equivalent are ignored.
+func NewWriter(w io.Writer, lang Language, t *ticket.Ticket) *Writer {
+	pw := &Writer{w: w, lang: lang}
+	if t != nil {
+		pw.t = *t
+	}
+	return pw
+}
+
+// quote returns s as PJL string. PJL strings cannot contain quotes
+// and control characters.
+func quote(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < ' ' || r == '"' || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+	return `"` + s + `"`
+}
+
+// Header returns PJL commands that start job of language lang with
+// options t.
+func Header(lang Language, t *ticket.Ticket) []byte {
+	if t == nil {
+		t = &ticket.Ticket{}
+	}
+	var b bytes.Buffer
+	set := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, "@PJL SET "+format+"\r\n", args...)
+	}
+	b.WriteString(UEL + "@PJL\r\n")
+	b.WriteString("@PJL JOB")
+	if t.JobName != "" {
+		b.WriteString(" NAME = " + quote(t.JobName))
+	}
+	b.WriteString("\r\n")
+	if t.UserName != "" {
+		set("USERNAME = %s", quote(t.UserName))
+	}
+	if t.Copies > 0 {
+		if t.Collate {
+			// QTY prints copies of the whole job.
+			set("QTY = %d", t.Copies)
+		} else {
+			set("COPIES = %d", t.Copies)
+		}
+	}
+	switch t.Duplex {
+	case ticket.OneSided:
+		set("DUPLEX = OFF")
+	case ticket.TwoSidedLongEdge:
+		set("DUPLEX = ON")
+		set("BINDING = LONGEDGE")
+	case ticket.TwoSidedShortEdge:
+		set("DUPLEX = ON")
+		set("BINDING = SHORTEDGE")
+	}
+	if p, ok := papers[t.Media.Name]; ok {
+		set("PAPER = %s", p)
+	}
+	if s, ok := trays[t.Tray]; ok {
+		set("MEDIASOURCE = %s", s)
+	}
+	if t.Resolution > 0 {
+		set("RESOLUTION = %d", t.Resolution)
+	}
+	switch t.Color {
+	case ticket.Monochrome:
+		set("RENDERMODE = GRAYSCALE")
+	case ticket.Color:
+		set("RENDERMODE = COLOR")
+	}
+	fmt.Fprintf(&b, "@PJL ENTER LANGUAGE = %s\r\n", lang)
+	return b.Bytes()
+}
+
+// Footer returns PJL commands that finish job started by Header.
+func Footer(t *ticket.Ticket) []byte {
+	var b bytes.Buffer
+	b.WriteString(UEL + "@PJL EOJ")
+	if t != nil && t.JobName != "" {
+		b.WriteString(" NAME = " + quote(t.JobName))
+	}
+	b.WriteString("\r\n" + UEL)
+	return b.Bytes()
+}
+
+func (pw *Writer) writeHeader() error {
+	if pw.header {
+		return nil
+	}
+	pw.header = true
+	_, err := pw.w.Write(Header(pw.lang, &pw.t))
+	return err
+}
+
+// Write writes job data b.
+func (pw *Writer) Write(b []byte) (int, error) {
+	if pw.closed {
+		return 0, ErrClosed
+	}
+	if err := pw.writeHeader(); err != nil {
+		return 0, err
+	}
+	return pw.w.Write(b)
+}
+
+// Close finishes the job. It does not close the underlying writer.
+func (pw *Writer) Close() error {
+	if pw.closed {
+		return ErrClosed
+	}
+	pw.closed = true
+	if err := pw.writeHeader(); err != nil {
+		return err
+	}
+	_, err := pw.w.Write(Footer(&pw.t))
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pjl
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+	"github.com/alexbrainman/printer/ticket"
+)
+
+func TestWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, PCL, &ticket.Ticket{
+		JobName:    `my "report"`,
+		UserName:   "alex",
+		Copies:     2,
+		Duplex:     ticket.TwoSidedLongEdge,
+		Media:      media.A4,
+		Tray:       "tray-2",
+		Resolution: 600,
+		Color:      ticket.Monochrome,
+		Staple:     true,
+	})
+	if _, err := io.WriteString(w, "\x1bEhello\f\x1bE"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b%-12345X@PJL\r\n" +
+		"@PJL JOB NAME = \"my  report \"\r\n" +
+		"@PJL SET USERNAME = \"alex\"\r\n" +
+		"@PJL SET COPIES = 2\r\n" +
+		"@PJL SET DUPLEX = ON\r\n" +
+		"@PJL SET BINDING = LONGEDGE\r\n" +
+		"@PJL SET PAPER = A4\r\n" +
+		"@PJL SET MEDIASOURCE = TRAY2\r\n" +
+		"@PJL SET RESOLUTION = 600\r\n" +
+		"@PJL SET RENDERMODE = GRAYSCALE\r\n" +
+		"@PJL ENTER LANGUAGE = PCL\r\n" +
+		"\x1bEhello\f\x1bE" +
+		"\x1b%-12345X@PJL EOJ NAME = \"my  report \"\r\n\x1b%-12345X"
+	if got := b.String(); got != want {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+	if _, err := w.Write([]byte("x")); err != ErrClosed {
+		t.Errorf("Write after Close: got %v, want %v", err, ErrClosed)
+	}
+	if err := w.Close(); err != ErrClosed {
+		t.Errorf("second Close: got %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestWriterDefaults(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, PostScript, nil)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b%-12345X@PJL\r\n@PJL JOB\r\n@PJL ENTER LANGUAGE = POSTSCRIPT\r\n" +
+		"\x1b%-12345X@PJL EOJ\r\n\x1b%-12345X"
+	if got := b.String(); got != want {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestHeaderCollate(t *testing.T) {
+	h := string(Header(PCLXL, &ticket.Ticket{
+		Copies:  3,
+		Collate: true,
+		Duplex:  ticket.OneSided,
+		Media:   media.Letter,
+		Tray:    "auto",
+		Color:   ticket.Color,
+	}))
+	want := "\x1b%-12345X@PJL\r\n@PJL JOB\r\n" +
+		"@PJL SET QTY = 3\r\n" +
+		"@PJL SET DUPLEX = OFF\r\n" +
+		"@PJL SET PAPER = LETTER\r\n" +
+		"@PJL SET RENDERMODE = COLOR\r\n" +
+		"@PJL ENTER LANGUAGE = PCLXL\r\n"
+	if h != want {
+		t.Errorf("got %q\nwant %q", h, want)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+type PORT_INFO_1 struct {
+	Name *uint16
+}
+
+type PORT_INFO_2 struct {
+	PortName    *uint16
+	MonitorName *uint16
+	Description *uint16
+	PortType    uint32
+	Reserved    uint32
+}
+
+type MONITOR_INFO_1 struct {
+	Name *uint16
+}
+
+type MONITOR_INFO_2 struct {
+	Name        *uint16
+	Environment *uint16
+	DLLName     *uint16
+}
+
+const (
+	PORT_TYPE_WRITE        = 0x0001
+	PORT_TYPE_READ         = 0x0002
+	PORT_TYPE_REDIRECTED   = 0x0004
+	PORT_TYPE_NET_ATTACHED = 0x0008
+)
+
+//sys	EnumPorts(name *uint16, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) = winspool.EnumPortsW
+//sys	EnumMonitors(name *uint16, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) = winspool.EnumMonitorsW
+
+// PortInfo stores information about a printer port.
+type PortInfo struct {
+	Name        string
+	MonitorName string
+	Description string
+	Type        uint32 // combination of PORT_TYPE_* flags
+}
+
+// MonitorInfo stores information about a port monitor.
+type MonitorInfo struct {
+	Name        string
+	Environment string
+	DLLName     string
+}
+
+type enumFunc func(name *uint16, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) error
+
+// enum calls EnumPorts or EnumMonitors (passed as f) and returns
+// buffer filled with level structures and number of structures returned.
+// If level is not supported, enum retries with level 1.
+func enum(f enumFunc, level uint32) ([]byte, uint32, uint32, error) {
+	var needed, returned uint32
+	buf := make([]byte, 1)
+	for {
+		err := f(nil, level, &buf[0], uint32(len(buf)), &needed, &returned)
+		if err == nil {
+			return buf, returned, level, nil
+		}
+		if err == windows.ERROR_INVALID_LEVEL && level > 1 {
+			level = 1
+			continue
+		}
+		if err != syscall.ERROR_INSUFFICIENT_BUFFER {
+			return nil, 0, 0, err
+		}
+		if needed <= uint32(len(buf)) {
+			return nil, 0, 0, err
+		}
+		buf = make([]byte, needed)
+	}
+}
+
+func utf16PtrToString(p *uint16) string {
+	if p == nil {
+		return ""
+	}
+	return windows.UTF16PtrToString(p)
+}
+
+// decodePorts converts n PORT_INFO_1 or PORT_INFO_2 (depending on
+// level) structures stored in buf into PortInfo.
+func decodePorts(buf []byte, level, n uint32) []PortInfo {
+	if n == 0 {
+		return nil
+	}
+	ports := make([]PortInfo, 0, n)
+	switch level {
+	case 1:
+		for _, pi := range (*[1 << 20]PORT_INFO_1)(unsafe.Pointer(&buf[0]))[:n:n] {
+			ports = append(ports, PortInfo{Name: utf16PtrToString(pi.Name)})
+		}
+	case 2:
+		for _, pi := range (*[1 << 20]PORT_INFO_2)(unsafe.Pointer(&buf[0]))[:n:n] {
+			ports = append(ports, PortInfo{
+				Name:        utf16PtrToString(pi.PortName),
+				MonitorName: utf16PtrToString(pi.MonitorName),
+				Description: utf16PtrToString(pi.Description),
+				Type:        pi.PortType,
+			})
+		}
+	}
+	return ports
+}
+
+// decodeMonitors converts n MONITOR_INFO_1 or MONITOR_INFO_2
+// (depending on level) structures stored in buf into MonitorInfo.
+func decodeMonitors(buf []byte, level, n uint32) []MonitorInfo {
+	if n == 0 {
+		return nil
+	}
+	monitors := make([]MonitorInfo, 0, n)
+	switch level {
+	case 1:
+		for _, mi := range (*[1 << 20]MONITOR_INFO_1)(unsafe.Pointer(&buf[0]))[:n:n] {
+			monitors = append(monitors, MonitorInfo{Name: utf16PtrToString(mi.Name)})
+		}
+	case 2:
+		for _, mi := range (*[1 << 20]MONITOR_INFO_2)(unsafe.Pointer(&buf[0]))[:n:n] {
+			monitors = append(monitors, MonitorInfo{
+				Name:        utf16PtrToString(mi.Name),
+				Environment: utf16PtrToString(mi.Environment),
+				DLLName:     utf16PtrToString(mi.DLLName),
+			})
+		}
+	}
+	return monitors
+}
+
+// Ports returns printer ports on the system.
+func Ports() ([]PortInfo, error) {
+	buf, n, level, err := enum(EnumPorts, 2)
+	if err != nil {
+		return nil, err
+	}
+	return decodePorts(buf, level, n), nil
+}
+
+// Monitors returns port monitors installed on the system.
+func Monitors() ([]MonitorInfo, error) {
+	buf, n, level, err := enum(EnumMonitors, 2)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMonitors(buf, level, n), nil
+}
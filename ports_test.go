@@ -0,0 +1,96 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"runtime"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// asBytes returns size bytes of memory starting at p. It is used to
+// present Go structures as buffers returned by winspool Enum* functions.
+func asBytes(p unsafe.Pointer, size uintptr) []byte {
+	return (*[1 << 30]byte)(p)[:size:size]
+}
+
+func TestDecodePorts(t *testing.T) {
+	s := syscall.StringToUTF16Ptr
+	ports2 := []PORT_INFO_2{
+		{PortName: s("LPT1:"), MonitorName: s("Local Port"), Description: s("Printer Port"), PortType: PORT_TYPE_WRITE | PORT_TYPE_READ},
+		{PortName: s("IP_10.0.0.5"), MonitorName: s("Standard TCP/IP Port"), PortType: PORT_TYPE_WRITE | PORT_TYPE_NET_ATTACHED},
+	}
+	buf := asBytes(unsafe.Pointer(&ports2[0]), unsafe.Sizeof(ports2[0])*uintptr(len(ports2)))
+	ports := decodePorts(buf, 2, uint32(len(ports2)))
+	runtime.KeepAlive(ports2)
+	want := []PortInfo{
+		{Name: "LPT1:", MonitorName: "Local Port", Description: "Printer Port", Type: PORT_TYPE_WRITE | PORT_TYPE_READ},
+		{Name: "IP_10.0.0.5", MonitorName: "Standard TCP/IP Port", Type: PORT_TYPE_WRITE | PORT_TYPE_NET_ATTACHED},
+	}
+	if len(ports) != len(want) {
+		t.Fatalf("decodePorts returned %d ports, want %d", len(ports), len(want))
+	}
+	for i := range want {
+		if ports[i] != want[i] {
+			t.Errorf("port %d is %+v, want %+v", i, ports[i], want[i])
+		}
+	}
+
+	ports1 := []PORT_INFO_1{{Name: s("COM1:")}}
+	buf = asBytes(unsafe.Pointer(&ports1[0]), unsafe.Sizeof(ports1[0]))
+	ports = decodePorts(buf, 1, 1)
+	runtime.KeepAlive(ports1)
+	if len(ports) != 1 || ports[0] != (PortInfo{Name: "COM1:"}) {
+		t.Errorf("decodePorts level 1 returned %+v", ports)
+	}
+
+	if ports := decodePorts(buf, 2, 0); ports != nil {
+		t.Errorf("decodePorts with no ports returned %+v", ports)
+	}
+}
+
+func TestDecodeMonitors(t *testing.T) {
+	s := syscall.StringToUTF16Ptr
+	monitors2 := []MONITOR_INFO_2{
+		{Name: s("Local Port"), Environment: s("Windows x64"), DLLName: s("localspl.dll")},
+		{Name: s("Standard TCP/IP Port"), Environment: s("Windows x64"), DLLName: s("tcpmon.dll")},
+	}
+	buf := asBytes(unsafe.Pointer(&monitors2[0]), unsafe.Sizeof(monitors2[0])*uintptr(len(monitors2)))
+	monitors := decodeMonitors(buf, 2, uint32(len(monitors2)))
+	runtime.KeepAlive(monitors2)
+	want := []MonitorInfo{
+		{Name: "Local Port", Environment: "Windows x64", DLLName: "localspl.dll"},
+		{Name: "Standard TCP/IP Port", Environment: "Windows x64", DLLName: "tcpmon.dll"},
+	}
+	if len(monitors) != len(want) {
+		t.Fatalf("decodeMonitors returned %d monitors, want %d", len(monitors), len(want))
+	}
+	for i := range want {
+		if monitors[i] != want[i] {
+			t.Errorf("monitor %d is %+v, want %+v", i, monitors[i], want[i])
+		}
+	}
+}
+
+func TestPortsAndMonitors(t *testing.T) {
+	ports, err := Ports()
+	if err != nil {
+		t.Fatalf("Ports failed: %v", err)
+	}
+	for _, p := range ports {
+		t.Logf("port: %+v", p)
+	}
+	monitors, err := Monitors()
+	if err != nil {
+		t.Fatalf("Monitors failed: %v", err)
+	}
+	for _, m := range monitors {
+		t.Logf("monitor: %+v", m)
+	}
+}
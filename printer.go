@@ -2,10 +2,15 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build windows
+// +build windows
+
 // Windows printing.
 package printer
 
 import (
+	"bufio"
+	"io"
 	"strings"
 	"syscall"
 	"time"
@@ -14,7 +19,7 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-//go:generate go run mksyscall_windows.go -output zapi.go printer.go
+//go:generate go run mksyscall_windows.go -output zapi_windows.go printer.go forms.go ports.go admin.go watch.go document.go read.go
 
 type DOC_INFO_1 struct {
 	DocName    *uint16
@@ -74,9 +79,23 @@ type JOB_INFO_1 struct {
 	Submitted    syscall.Systemtime
 }
 
+type PRINTER_INFO_1 struct {
+	Flags       uint32
+	Description *uint16
+	Name        *uint16
+	Comment     *uint16
+}
+
 const (
-	PRINTER_ENUM_LOCAL       = 2
-	PRINTER_ENUM_CONNECTIONS = 4
+	PRINTER_ENUM_DEFAULT     = 0x00000001
+	PRINTER_ENUM_LOCAL       = 0x00000002
+	PRINTER_ENUM_CONNECTIONS = 0x00000004
+	PRINTER_ENUM_NAME        = 0x00000008
+	PRINTER_ENUM_REMOTE      = 0x00000010
+	PRINTER_ENUM_SHARED      = 0x00000020
+	PRINTER_ENUM_NETWORK     = 0x00000040
+	PRINTER_ENUM_EXPAND      = 0x00004000
+	PRINTER_ENUM_CONTAINER   = 0x00008000
 
 	PRINTER_DRIVER_XPS = 0x00000002
 )
@@ -100,9 +119,10 @@ const (
 )
 
 //sys	GetDefaultPrinter(buf *uint16, bufN *uint32) (err error) = winspool.GetDefaultPrinterW
+//sys	SetDefaultPrinter(name *uint16) (err error) = winspool.SetDefaultPrinterW
 //sys	ClosePrinter(h syscall.Handle) (err error) = winspool.ClosePrinter
 //sys	OpenPrinter(name *uint16, h *syscall.Handle, defaults uintptr) (err error) = winspool.OpenPrinterW
-//sys	StartDocPrinter(h syscall.Handle, level uint32, docinfo *DOC_INFO_1) (err error) = winspool.StartDocPrinterW
+//sys	StartDocPrinter(h syscall.Handle, level uint32, docinfo *DOC_INFO_1) (jobID uint32, err error) = winspool.StartDocPrinterW
 //sys	EndDocPrinter(h syscall.Handle) (err error) = winspool.EndDocPrinter
 //sys	WritePrinter(h syscall.Handle, buf *byte, bufN uint32, written *uint32) (err error) = winspool.WritePrinter
 //sys	StartPagePrinter(h syscall.Handle) (err error) = winspool.StartPagePrinter
@@ -110,6 +130,7 @@ const (
 //sys	EnumPrinters(flags uint32, name *uint16, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) = winspool.EnumPrintersW
 //sys	GetPrinterDriver(h syscall.Handle, env *uint16, level uint32, di *byte, n uint32, needed *uint32) (err error) = winspool.GetPrinterDriverW
 //sys	EnumJobs(h syscall.Handle, firstJob uint32, noJobs uint32, level uint32, buf *byte, bufN uint32, bytesNeeded *uint32, jobsReturned *uint32) (err error) = winspool.EnumJobsW
+//sys	GetJob(h syscall.Handle, jobID uint32, level uint32, buf *byte, bufN uint32, needed *uint32) (err error) = winspool.GetJobW
 
 func Default() (string, error) {
 	b := make([]uint16, 3)
@@ -128,6 +149,11 @@ func Default() (string, error) {
 	return syscall.UTF16ToString(b), nil
 }
 
+// SetDefault makes printer name default printer for current user.
+func SetDefault(name string) error {
+	return SetDefaultPrinter(&(syscall.StringToUTF16(name))[0])
+}
+
 // ReadNames return printer names on the system
 func ReadNames() ([]string, error) {
 	const flags = PRINTER_ENUM_LOCAL | PRINTER_ENUM_CONNECTIONS
@@ -152,8 +178,82 @@ func ReadNames() ([]string, error) {
 	return names, nil
 }
 
+// EnumOptions selects printers listed by ReadNamesWith.
+type EnumOptions struct {
+	// Server is print server name, like `\\server`.
+	// Empty Server means local computer.
+	Server string
+	// Flags is combination of PRINTER_ENUM_* flags. Zero Flags
+	// means PRINTER_ENUM_LOCAL | PRINTER_ENUM_CONNECTIONS.
+	Flags uint32
+}
+
+// ReadNamesWith returns names of printers selected by opts. opts can be
+// nil. Print server and domain containers are not included.
+func ReadNamesWith(opts *EnumOptions) ([]string, error) {
+	if opts == nil {
+		opts = &EnumOptions{}
+	}
+	flags := opts.Flags
+	if flags == 0 {
+		flags = PRINTER_ENUM_LOCAL | PRINTER_ENUM_CONNECTIONS
+	}
+	var server *uint16
+	if opts.Server != "" {
+		server = &(syscall.StringToUTF16(opts.Server))[0]
+	}
+	var needed, returned uint32
+	buf := make([]byte, 1)
+	for {
+		err := EnumPrinters(flags, server, 1, &buf[0], uint32(len(buf)), &needed, &returned)
+		if err == nil {
+			break
+		}
+		if err != syscall.ERROR_INSUFFICIENT_BUFFER {
+			return nil, err
+		}
+		if needed <= uint32(len(buf)) {
+			return nil, err
+		}
+		buf = make([]byte, needed)
+	}
+	names := make([]string, 0, returned)
+	size := unsafe.Sizeof(PRINTER_INFO_1{})
+	for i := uintptr(0); i < uintptr(returned); i++ {
+		p := (*PRINTER_INFO_1)(unsafe.Pointer(&buf[i*size]))
+		if p.Flags&PRINTER_ENUM_CONTAINER != 0 {
+			continue
+		}
+		names = append(names, utf16PtrToString(p.Name))
+	}
+	return names, nil
+}
+
+// ReadNamesOn returns names of printers on print server,
+// like `\\server`.
+func ReadNamesOn(server string) ([]string, error) {
+	return ReadNamesWith(&EnumOptions{Server: server, Flags: PRINTER_ENUM_NAME})
+}
+
 type Printer struct {
-	h syscall.Handle
+	h        syscall.Handle
+	progress ProgressFunc
+
+	readDeadline time.Time
+	readPending  chan readResult // ReadPrinter calls that have not completed before deadline
+	readCancel   chan struct{}   // closed to stop readPending calls
+	readBuf      []byte          // data read, but not yet returned by Read
+}
+
+// ProgressFunc reports document data written by ReadFrom. sent is
+// number of bytes written so far, and total is number of bytes to
+// write, or -1, if unknown.
+type ProgressFunc func(sent, total int64)
+
+// SetProgress makes ReadFrom call f after every chunk of data
+// written to printer p. Use nil f to stop progress reports.
+func (p *Printer) SetProgress(f ProgressFunc) {
+	p.progress = f
 }
 
 func Open(name string) (*Printer, error) {
@@ -190,12 +290,104 @@ type JobInfo struct {
 	Submitted       time.Time
 }
 
-// Jobs returns information about all print jobs on this printer
-func (p *Printer) Jobs() ([]JobInfo, error) {
+// jobsPageSize is number of jobs requested by every EnumJobs call.
+const jobsPageSize = 256
+
+// newJobInfo converts JOB_INFO_1 into JobInfo.
+func newJobInfo(j *JOB_INFO_1) JobInfo {
+	pji := JobInfo{
+		JobID:        j.JobID,
+		StatusCode:   j.StatusCode,
+		Priority:     j.Priority,
+		Position:     j.Position,
+		TotalPages:   j.TotalPages,
+		PagesPrinted: j.PagesPrinted,
+	}
+	if j.MachineName != nil {
+		pji.UserMachineName = windows.UTF16PtrToString(j.MachineName)
+	}
+	if j.UserName != nil {
+		pji.UserName = windows.UTF16PtrToString(j.UserName)
+	}
+	if j.Document != nil {
+		pji.DocumentName = windows.UTF16PtrToString(j.Document)
+	}
+	if j.DataType != nil {
+		pji.DataType = windows.UTF16PtrToString(j.DataType)
+	}
+	if j.Status != nil {
+		pji.Status = windows.UTF16PtrToString(j.Status)
+	}
+	if strings.TrimSpace(pji.Status) == "" {
+		if pji.StatusCode == 0 {
+			pji.Status += "Queue Paused, "
+		}
+		if pji.StatusCode&JOB_STATUS_PRINTING != 0 {
+			pji.Status += "Printing, "
+		}
+		if pji.StatusCode&JOB_STATUS_PAUSED != 0 {
+			pji.Status += "Paused, "
+		}
+		if pji.StatusCode&JOB_STATUS_ERROR != 0 {
+			pji.Status += "Error, "
+		}
+		if pji.StatusCode&JOB_STATUS_DELETING != 0 {
+			pji.Status += "Deleting, "
+		}
+		if pji.StatusCode&JOB_STATUS_SPOOLING != 0 {
+			pji.Status += "Spooling, "
+		}
+		if pji.StatusCode&JOB_STATUS_OFFLINE != 0 {
+			pji.Status += "Printer Offline, "
+		}
+		if pji.StatusCode&JOB_STATUS_PAPEROUT != 0 {
+			pji.Status += "Out of Paper, "
+		}
+		if pji.StatusCode&JOB_STATUS_PRINTED != 0 {
+			pji.Status += "Printed, "
+		}
+		if pji.StatusCode&JOB_STATUS_DELETED != 0 {
+			pji.Status += "Deleted, "
+		}
+		if pji.StatusCode&JOB_STATUS_BLOCKED_DEVQ != 0 {
+			pji.Status += "Driver Error, "
+		}
+		if pji.StatusCode&JOB_STATUS_USER_INTERVENTION != 0 {
+			pji.Status += "User Action Required, "
+		}
+		if pji.StatusCode&JOB_STATUS_RESTART != 0 {
+			pji.Status += "Restarted, "
+		}
+		if pji.StatusCode&JOB_STATUS_COMPLETE != 0 {
+			pji.Status += "Sent to Printer, "
+		}
+		if pji.StatusCode&JOB_STATUS_RETAINED != 0 {
+			pji.Status += "Retained, "
+		}
+		if pji.StatusCode&JOB_STATUS_RENDERING_LOCALLY != 0 {
+			pji.Status += "Rendering on Client, "
+		}
+		pji.Status = strings.TrimRight(pji.Status, ", ")
+	}
+	pji.Submitted = time.Date(
+		int(j.Submitted.Year),
+		time.Month(int(j.Submitted.Month)),
+		int(j.Submitted.Day),
+		int(j.Submitted.Hour),
+		int(j.Submitted.Minute),
+		int(j.Submitted.Second),
+		int(1000*j.Submitted.Milliseconds),
+		time.Local,
+	).UTC()
+	return pji
+}
+
+// enumJobs returns up to n jobs starting at queue position first.
+func (p *Printer) enumJobs(first, n uint32) ([]JobInfo, error) {
 	var bytesNeeded, jobsReturned uint32
 	buf := make([]byte, 1)
 	for {
-		err := EnumJobs(p.h, 0, 255, 1, &buf[0], uint32(len(buf)), &bytesNeeded, &jobsReturned)
+		err := EnumJobs(p.h, first, n, 1, &buf[0], uint32(len(buf)), &bytesNeeded, &jobsReturned)
 		if err == nil {
 			break
 		}
@@ -211,97 +403,69 @@ func (p *Printer) Jobs() ([]JobInfo, error) {
 		return nil, nil
 	}
 	pjs := make([]JobInfo, 0, jobsReturned)
-	ji := (*[2048]JOB_INFO_1)(unsafe.Pointer(&buf[0]))[:jobsReturned:jobsReturned]
-	for _, j := range ji {
-		pji := JobInfo{
-			JobID:        j.JobID,
-			StatusCode:   j.StatusCode,
-			Priority:     j.Priority,
-			Position:     j.Position,
-			TotalPages:   j.TotalPages,
-			PagesPrinted: j.PagesPrinted,
-		}
-		if j.MachineName != nil {
-			pji.UserMachineName = windows.UTF16PtrToString(j.MachineName)
-		}
-		if j.UserName != nil {
-			pji.UserName = windows.UTF16PtrToString(j.UserName)
-		}
-		if j.Document != nil {
-			pji.DocumentName = windows.UTF16PtrToString(j.Document)
-		}
-		if j.DataType != nil {
-			pji.DataType = windows.UTF16PtrToString(j.DataType)
-		}
-		if j.Status != nil {
-			pji.Status = windows.UTF16PtrToString(j.Status)
-		}
-		if strings.TrimSpace(pji.Status) == "" {
-			if pji.StatusCode == 0 {
-				pji.Status += "Queue Paused, "
-			}
-			if pji.StatusCode&JOB_STATUS_PRINTING != 0 {
-				pji.Status += "Printing, "
-			}
-			if pji.StatusCode&JOB_STATUS_PAUSED != 0 {
-				pji.Status += "Paused, "
-			}
-			if pji.StatusCode&JOB_STATUS_ERROR != 0 {
-				pji.Status += "Error, "
-			}
-			if pji.StatusCode&JOB_STATUS_DELETING != 0 {
-				pji.Status += "Deleting, "
-			}
-			if pji.StatusCode&JOB_STATUS_SPOOLING != 0 {
-				pji.Status += "Spooling, "
-			}
-			if pji.StatusCode&JOB_STATUS_OFFLINE != 0 {
-				pji.Status += "Printer Offline, "
-			}
-			if pji.StatusCode&JOB_STATUS_PAPEROUT != 0 {
-				pji.Status += "Out of Paper, "
-			}
-			if pji.StatusCode&JOB_STATUS_PRINTED != 0 {
-				pji.Status += "Printed, "
-			}
-			if pji.StatusCode&JOB_STATUS_DELETED != 0 {
-				pji.Status += "Deleted, "
-			}
-			if pji.StatusCode&JOB_STATUS_BLOCKED_DEVQ != 0 {
-				pji.Status += "Driver Error, "
-			}
-			if pji.StatusCode&JOB_STATUS_USER_INTERVENTION != 0 {
-				pji.Status += "User Action Required, "
-			}
-			if pji.StatusCode&JOB_STATUS_RESTART != 0 {
-				pji.Status += "Restarted, "
-			}
-			if pji.StatusCode&JOB_STATUS_COMPLETE != 0 {
-				pji.Status += "Sent to Printer, "
-			}
-			if pji.StatusCode&JOB_STATUS_RETAINED != 0 {
-				pji.Status += "Retained, "
-			}
-			if pji.StatusCode&JOB_STATUS_RENDERING_LOCALLY != 0 {
-				pji.Status += "Rendering on Client, "
+	size := unsafe.Sizeof(JOB_INFO_1{})
+	for i := uintptr(0); i < uintptr(jobsReturned); i++ {
+		pjs = append(pjs, newJobInfo((*JOB_INFO_1)(unsafe.Pointer(&buf[i*size]))))
+	}
+	return pjs, nil
+}
+
+// JobsFunc calls fn for every print job on this printer. Jobs are read
+// from the print queue in pages, so any number of jobs is supported.
+// JobsFunc stops and returns error, if fn returns error. Jobs added
+// or removed while JobsFunc runs might be missed or reported twice.
+func (p *Printer) JobsFunc(fn func(JobInfo) error) error {
+	for first := uint32(0); ; first += jobsPageSize {
+		jobs, err := p.enumJobs(first, jobsPageSize)
+		if err != nil {
+			return err
+		}
+		for _, j := range jobs {
+			err := fn(j)
+			if err != nil {
+				return err
 			}
-			pji.Status = strings.TrimRight(pji.Status, ", ")
-		}
-		pji.Submitted = time.Date(
-			int(j.Submitted.Year),
-			time.Month(int(j.Submitted.Month)),
-			int(j.Submitted.Day),
-			int(j.Submitted.Hour),
-			int(j.Submitted.Minute),
-			int(j.Submitted.Second),
-			int(1000*j.Submitted.Milliseconds),
-			time.Local,
-		).UTC()
-		pjs = append(pjs, pji)
+		}
+		if len(jobs) < jobsPageSize {
+			return nil
+		}
+	}
+}
+
+// Jobs returns information about all print jobs on this printer
+func (p *Printer) Jobs() ([]JobInfo, error) {
+	var pjs []JobInfo
+	err := p.JobsFunc(func(j JobInfo) error {
+		pjs = append(pjs, j)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return pjs, nil
 }
 
+// Job returns information about print job id.
+func (p *Printer) Job(id uint32) (*JobInfo, error) {
+	var needed uint32
+	buf := make([]byte, 1)
+	for {
+		err := GetJob(p.h, id, 1, &buf[0], uint32(len(buf)), &needed)
+		if err == nil {
+			break
+		}
+		if err != syscall.ERROR_INSUFFICIENT_BUFFER {
+			return nil, err
+		}
+		if needed <= uint32(len(buf)) {
+			return nil, err
+		}
+		buf = make([]byte, needed)
+	}
+	j := newJobInfo((*JOB_INFO_1)(unsafe.Pointer(&buf[0])))
+	return &j, nil
+}
+
 // DriverInfo returns information about printer p driver.
 func (p *Printer) DriverInfo() (*DriverInfo, error) {
 	var needed uint32
@@ -334,32 +498,130 @@ func (p *Printer) StartDocument(name, datatype string) error {
 		OutputFile: nil,
 		Datatype:   &(syscall.StringToUTF16(datatype))[0],
 	}
-	return StartDocPrinter(p.h, 1, &d)
+	_, err := StartDocPrinter(p.h, 1, &d)
+	return err
 }
 
-// StartRawDocument calls StartDocument and passes either "RAW" or "XPS_PASS"
-// as a document type, depending if printer driver is XPS-based or not.
-func (p *Printer) StartRawDocument(name string) error {
+// rawDatatype returns either "RAW" or "XPS_PASS" document type,
+// depending if printer driver is XPS-based or not.
+func (p *Printer) rawDatatype() (string, error) {
 	di, err := p.DriverInfo()
 	if err != nil {
-		return err
+		return "", err
 	}
 	// See https://support.microsoft.com/en-us/help/2779300/v4-print-drivers-using-raw-mode-to-send-pcl-postscript-directly-to-the
 	// for details.
-	datatype := "RAW"
 	if di.Attributes&PRINTER_DRIVER_XPS != 0 {
-		datatype = "XPS_PASS"
+		return "XPS_PASS", nil
+	}
+	return "RAW", nil
+}
+
+// StartRawDocument calls StartDocument and passes either "RAW" or "XPS_PASS"
+// as a document type, depending if printer driver is XPS-based or not.
+func (p *Printer) StartRawDocument(name string) error {
+	datatype, err := p.rawDatatype()
+	if err != nil {
+		return err
 	}
 	return p.StartDocument(name, datatype)
 }
 
-func (p *Printer) Write(b []byte) (int, error) {
-	var written uint32
-	err := WritePrinter(p.h, &b[0], uint32(len(b)), &written)
+// StartAutoDocument calls StartDocument with document type chosen
+// by format of document data read from r (see DetectFormat). XPS
+// documents are sent as "XPS_PASS", and other formats as StartRawDocument
+// does. StartAutoDocument reads beginning of r to detect the format,
+// so it returns reader of the whole document data that should be
+// written to p, for example with io.Copy(p, r).
+func (p *Printer) StartAutoDocument(name string, r io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	b, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	datatype := "XPS_PASS"
+	if DetectFormat(b) != FormatXPS {
+		datatype, err = p.rawDatatype()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = p.StartDocument(name, datatype)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	return br, nil
+}
+
+// maxWriteSize is the largest amount of data passed to single
+// WritePrinter call.
+const maxWriteSize = 1 << 30
+
+// Write writes b to printer p. It calls WritePrinter as many times
+// as necessary to write all of b.
+func (p *Printer) Write(b []byte) (int, error) {
+	n := 0
+	for n < len(b) {
+		chunk := len(b) - n
+		if chunk > maxWriteSize {
+			chunk = maxWriteSize
+		}
+		var written uint32
+		err := WritePrinter(p.h, &b[n], uint32(chunk), &written)
+		if err != nil {
+			return n, err
+		}
+		if written == 0 {
+			return n, io.ErrShortWrite
+		}
+		n += int(written)
+	}
+	return n, nil
+}
+
+// readChunkSize is size of chunks ReadFrom reads and writes.
+const readChunkSize = 64 << 10
+
+// ReadFrom writes data read from r to printer p until io.EOF, so large
+// documents can be streamed with io.Copy. If r implements io.Seeker,
+// ReadFrom uses it to find total document size for progress reports
+// (see SetProgress).
+func (p *Printer) ReadFrom(r io.Reader) (int64, error) {
+	total := int64(-1)
+	if s, ok := r.(io.Seeker); ok {
+		cur, err := s.Seek(0, io.SeekCurrent)
+		if err == nil {
+			end, err := s.Seek(0, io.SeekEnd)
+			if err == nil {
+				total = end - cur
+			}
+			_, err = s.Seek(cur, io.SeekStart)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	buf := make([]byte, readChunkSize)
+	var sent int64
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			written, werr := p.Write(buf[:n])
+			sent += int64(written)
+			if werr != nil {
+				return sent, werr
+			}
+			if p.progress != nil {
+				p.progress(sent, total)
+			}
+		}
+		if err == io.EOF {
+			return sent, nil
+		}
+		if err != nil {
+			return sent, err
+		}
 	}
-	return int(written), nil
 }
 
 func (p *Printer) EndDocument() error {
@@ -374,6 +636,10 @@ func (p *Printer) EndPage() error {
 	return EndPagePrinter(p.h)
 }
 
+// Close closes printer p. If Read timed out, Close stops waiting for
+// printer data, and waits up to one second for ReadPrinter call that
+// is still using the printer handle, before closing the handle anyway.
 func (p *Printer) Close() error {
+	p.cancelRead()
 	return ClosePrinter(p.h)
 }
@@ -2,11 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build windows
+// +build windows
+
 package printer
 
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -103,3 +108,132 @@ func TestJobs(t *testing.T) {
 		}
 	}
 }
+
+func TestForms(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	forms, err := p.Forms()
+	if err != nil {
+		t.Fatalf("Forms failed: %v", err)
+	}
+	for _, f := range forms {
+		m, _ := f.Media()
+		t.Logf("%+v %q", f, m.Name)
+	}
+}
+
+func TestJob(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	n := 0
+	err = p.JobsFunc(func(j JobInfo) error {
+		n++
+		j2, err := p.Job(j.JobID)
+		if err != nil {
+			// job might have completed already
+			t.Logf("Job(%d) failed: %v", j.JobID, err)
+			return nil
+		}
+		if j2.JobID != j.JobID {
+			t.Errorf("Job(%d) returned job %d", j.JobID, j2.JobID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("JobsFunc failed: %v", err)
+	}
+	t.Logf("%d jobs found", n)
+}
+
+func TestReadNamesWith(t *testing.T) {
+	names, err := ReadNames()
+	if err != nil {
+		t.Fatalf("ReadNames failed: %v", err)
+	}
+	names2, err := ReadNamesWith(nil)
+	if err != nil {
+		t.Fatalf("ReadNamesWith failed: %v", err)
+	}
+	if len(names) != len(names2) {
+		t.Fatalf("ReadNames returned %q, but ReadNamesWith returned %q", names, names2)
+	}
+	for i := range names {
+		if names[i] != names2[i] {
+			t.Fatalf("ReadNames returned %q, but ReadNamesWith returned %q", names, names2)
+		}
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Fatalf("Hostname failed: %v", err)
+	}
+	names, err = ReadNamesOn(`\\` + host)
+	if err != nil {
+		t.Fatalf("ReadNamesOn failed: %v", err)
+	}
+	t.Logf("printers on %s: %q", host, names)
+}
+
+func TestReadFrom(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	err = p.StartDocument("my document", "RAW")
+	if err != nil {
+		t.Fatalf("StartDocument failed: %v", err)
+	}
+	defer p.EndDocument()
+	err = p.StartPage()
+	if err != nil {
+		t.Fatalf("StartPage failed: %v", err)
+	}
+	n, err := p.Write(nil)
+	if n != 0 || err != nil {
+		t.Fatalf("Write(nil) returned %d, %v", n, err)
+	}
+	var lastSent, lastTotal int64
+	p.SetProgress(func(sent, total int64) {
+		lastSent, lastTotal = sent, total
+	})
+	text := strings.Repeat(fmt.Sprintf("Hello %q\r\n", name), 10000)
+	written, err := p.ReadFrom(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if written != int64(len(text)) {
+		t.Fatalf("ReadFrom wrote %d bytes, want %d", written, len(text))
+	}
+	if lastSent != written || lastTotal != written {
+		t.Fatalf("last progress report is (%d, %d), want (%d, %d)", lastSent, lastTotal, written, written)
+	}
+	err = p.EndPage()
+	if err != nil {
+		t.Fatalf("EndPage failed: %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ps
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alexbrainman/printer/ticket"
+)
+
+var ErrPPD = errors.New("ps: invalid PPD file")
+
+// PPD is PostScript Printer Description file. Only user interface
+// options are parsed, other PPD keywords are ignored.
+type PPD struct {
+	Options []*Option // in file order
+
+	dims map[string]string // PaperDimension values
+}
+
+// Option is user interface option, like "Duplex" or "InputSlot".
+type Option struct {
+	Keyword string // main keyword without '*'
+	Text    string // translation string, like "2-Sided Printing"
+	Default string
+	Section string // OrderDependency section, like "AnySetup"
+	Order   float64
+	Choices []Choice
+}
+
+// Choice is option value and PostScript code that selects it.
+type Choice struct {
+	Name string // option keyword, like "DuplexNoTumble"
+	Text string // translation string
+	Code string
+}
+
+// ppdLine is PPD statement, like `*Duplex None/Off: "code"`.
+type ppdLine struct {
+	keyword, option, text, value string
+}
+
+// ParsePPD parses PPD file read from r.
+func ParsePPD(r io.Reader) (*PPD, error) {
+	var lines []ppdLine
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	first := true
+	for s.Scan() {
+		t := s.Text()
+		if first {
+			if !strings.HasPrefix(strings.TrimPrefix(t, "\ufeff"), "*PPD-Adobe:") {
+				return nil, ErrPPD
+			}
+			first = false
+		}
+		if !strings.HasPrefix(t, "*") || strings.HasPrefix(t, "*%") || t == "*End" {
+			continue
+		}
+		var l ppdLine
+		head, value := t[1:], ""
+		if i := strings.IndexByte(head, ':'); i >= 0 {
+			head, value = head[:i], strings.TrimSpace(head[i+1:])
+		}
+		l.keyword = head
+		if i := strings.IndexAny(head, " \t"); i >= 0 {
+			l.keyword, l.option = head[:i], strings.TrimSpace(head[i+1:])
+			if j := strings.IndexByte(l.option, '/'); j >= 0 {
+				l.option, l.text = l.option[:j], l.option[j+1:]
+			}
+		}
+		if strings.HasPrefix(value, `"`) {
+			// Quoted value can span many lines.
+			for strings.Count(value, `"`) < 2 {
+				if !s.Scan() {
+					return nil, ErrPPD
+				}
+				value += "\n" + s.Text()
+			}
+			value = value[1:strings.LastIndexByte(value, '"')]
+		}
+		l.value = value
+		lines = append(lines, l)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if first {
+		return nil, ErrPPD
+	}
+	p := &PPD{dims: make(map[string]string)}
+	options := make(map[string]*Option)
+	for _, l := range lines {
+		switch l.keyword {
+		case "OpenUI", "JCLOpenUI":
+			o := &Option{Keyword: strings.TrimPrefix(l.option, "*"), Text: l.text}
+			if options[o.Keyword] == nil {
+				options[o.Keyword] = o
+				p.Options = append(p.Options, o)
+			}
+		case "PaperDimension":
+			p.dims[l.option] = l.value
+		}
+	}
+	for _, l := range lines {
+		switch {
+		case l.keyword == "OrderDependency":
+			// Value is like "10 AnySetup *Duplex".
+			f := strings.Fields(l.value)
+			if len(f) < 3 {
+				continue
+			}
+			if o := options[strings.TrimPrefix(f[2], "*")]; o != nil {
+				o.Order, _ = strconv.ParseFloat(f[0], 64)
+				o.Section = f[1]
+			}
+		case strings.HasPrefix(l.keyword, "Default") && l.option == "":
+			if o := options[l.keyword[len("Default"):]]; o != nil {
+				o.Default = l.value
+			}
+		case l.option != "":
+			if o := options[l.keyword]; o != nil {
+				o.Choices = append(o.Choices, Choice{Name: l.option, Text: l.text, Code: l.value})
+			}
+		}
+	}
+	return p, nil
+}
+
+// Option returns option with main keyword keyword, or nil.
+func (p *PPD) Option(keyword string) *Option {
+	for _, o := range p.Options {
+		if o.Keyword == keyword {
+			return o
+		}
+	}
+	return nil
+}
+
+// Choice returns option choice called name, or nil.
+func (o *Option) Choice(name string) *Choice {
+	for i := range o.Choices {
+		if o.Choices[i].Name == name {
+			return &o.Choices[i]
+		}
+	}
+	return nil
+}
+
+// Feature returns feature that selects choice of option keyword.
+func (p *PPD) Feature(keyword, choice string) (Feature, bool) {
+	o := p.Option(keyword)
+	if o == nil {
+		return Feature{}, false
+	}
+	c := o.Choice(choice)
+	if c == nil {
+		return Feature{}, false
+	}
+	return Feature{Keyword: keyword, Choice: c.Name, Code: c.Code}, true
+}
+
+// choose returns the first of names choices of option keyword.
+// Names are compared ignoring case and punctuation.
+func (p *PPD) choose(keyword string, names ...string) (*Option, *Choice) {
+	o := p.Option(keyword)
+	if o == nil {
+		return nil, nil
+	}
+	for _, name := range names {
+		for i := range o.Choices {
+			if normalize(o.Choices[i].Name) == normalize(name) {
+				return o, &o.Choices[i]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// normalize returns s in lower case without punctuation and spaces.
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', '0' <= r && r <= '9':
+			return r
+		case 'A' <= r && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return -1
+	}, s)
+}
+
+// pageSize returns PageSize choice that has paper dimensions
+// w by h points.
+func (p *PPD) pageSize(w, h int) string {
+	o := p.Option("PageSize")
+	if o == nil {
+		return ""
+	}
+	for _, c := range o.Choices {
+		var dw, dh float64
+		if _, err := fmt.Sscan(p.dims[c.Name], &dw, &dh); err != nil {
+			continue
+		}
+		if abs(dw-float64(w)) <= 2 && abs(dh-float64(h)) <= 2 {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Features returns features that set options t on printer described
+// by p. Options that printer does not support are ignored. Features
+// are sorted by PPD order, features that must be sent outside of
+// document, like JCL commands, are not included. t can be nil.
+func (p *PPD) Features(t *ticket.Ticket) []Feature {
+	if t == nil {
+		t = &ticket.Ticket{}
+	}
+	type choice struct {
+		keyword string
+		names   []string
+	}
+	var want []choice
+	if t.Media.Width > 0 && t.Media.Height > 0 {
+		if name := p.pageSize(points(t.Media.Width), points(t.Media.Height)); name != "" {
+			want = append(want, choice{"PageSize", []string{name}})
+		}
+	}
+	switch t.Tray {
+	case "":
+	case "manual":
+		want = append(want, choice{"InputSlot", []string{"Manual", "ManualFeed"}})
+		want = append(want, choice{"ManualFeed", []string{"True"}})
+	default:
+		want = append(want, choice{"InputSlot", []string{t.Tray}})
+	}
+	switch t.Duplex {
+	case ticket.OneSided:
+		want = append(want, choice{"Duplex", []string{"None", "Off", "Simplex"}})
+	case ticket.TwoSidedLongEdge:
+		want = append(want, choice{"Duplex", []string{"DuplexNoTumble", "LongEdge"}})
+	case ticket.TwoSidedShortEdge:
+		want = append(want, choice{"Duplex", []string{"DuplexTumble", "ShortEdge"}})
+	}
+	if t.Resolution > 0 {
+		want = append(want, choice{"Resolution", []string{
+			fmt.Sprintf("%ddpi", t.Resolution),
+			fmt.Sprintf("%dx%ddpi", t.Resolution, t.Resolution),
+		}})
+	}
+	switch t.Color {
+	case ticket.Monochrome:
+		want = append(want, choice{"ColorModel", []string{"Gray", "Grayscale", "Mono", "Monochrome"}})
+	case ticket.Color:
+		want = append(want, choice{"ColorModel", []string{"CMYK", "RGB", "Color"}})
+	}
+	if t.Collate {
+		want = append(want, choice{"Collate", []string{"True"}})
+	}
+	if t.Staple {
+		for _, kw := range []string{"StapleLocation", "Staple"} {
+			want = append(want, choice{kw, []string{"UpperLeft", "TopLeft", "SinglePortrait", "True", "On"}})
+		}
+	}
+	type feature struct {
+		Feature
+		order float64
+	}
+	var fs []feature
+	for _, w := range want {
+		o, c := p.choose(w.keyword, w.names...)
+		if c == nil || o.Section == "JCLSetup" || o.Section == "ExitServer" {
+			continue
+		}
+		fs = append(fs, feature{Feature{Keyword: o.Keyword, Choice: c.Name, Code: c.Code}, o.Order})
+	}
+	sort.SliceStable(fs, func(i, j int) bool { return fs[i].order < fs[j].order })
+	var r []Feature
+	for _, f := range fs {
+		r = append(r, f.Feature)
+	}
+	return append(r, copies(t)...)
+}
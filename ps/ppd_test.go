@@ -0,0 +1,152 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ps
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+	"github.com/alexbrainman/printer/ticket"
+)
+
+const testPPD = `*PPD-Adobe: "4.3"
+*% Test printer
+*FormatVersion: "4.3"
+*ModelName: "Test Laser"
+
+*OpenUI *PageSize/Media Size: PickOne
+*OrderDependency: 10 AnySetup *PageSize
+*DefaultPageSize: Letter
+*PageSize Letter/US Letter: "<</PageSize [612 792]>> setpagedevice"
+*PageSize A4/A4: "<</PageSize [595 842]>> setpagedevice"
+*CloseUI: *PageSize
+*PaperDimension Letter/US Letter: "612 792"
+*PaperDimension A4/A4: "595 842"
+
+*OpenUI *InputSlot/Paper Source: PickOne
+*OrderDependency: 20 AnySetup *InputSlot
+*DefaultInputSlot: Tray1
+*InputSlot Tray1/Tray 1: "<</MediaPosition 3>> setpagedevice"
+*InputSlot Tray2/Tray 2: "<</MediaPosition 0>> setpagedevice"
+*InputSlot ManualFeed/Manual Feed: "<</ManualFeed true>> setpagedevice"
+*CloseUI: *InputSlot
+
+*OpenUI *Duplex/2-Sided Printing: PickOne
+*OrderDependency: 5 AnySetup *Duplex
+*DefaultDuplex: None
+*Duplex None/Off: "
+  <</Duplex false>> setpagedevice"
+*End
+*Duplex DuplexNoTumble/Long Edge: "
+  <</Duplex true /Tumble false>>
+  setpagedevice"
+*End
+*Duplex DuplexTumble/Short Edge: "<</Duplex true /Tumble true>> setpagedevice"
+*CloseUI: *Duplex
+
+*OpenUI *StapleLocation/Staple: PickOne
+*OrderDependency: 50 DocumentSetup *StapleLocation
+*DefaultStapleLocation: None
+*StapleLocation None/Off: ""
+*StapleLocation UpperLeft/Upper Left: "<</Staple 3>> setpagedevice"
+*CloseUI: *StapleLocation
+
+*JCLOpenUI *JCLEconomode/Toner Saver: PickOne
+*OrderDependency: 10 JCLSetup *JCLEconomode
+*DefaultJCLEconomode: Off
+*JCLEconomode Off/Off: "@PJL SET ECONOMODE=OFF<0A>"
+*JCLCloseUI: *JCLEconomode
+`
+
+func TestParsePPD(t *testing.T) {
+	p, err := ParsePPD(strings.NewReader(testPPD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kws []string
+	for _, o := range p.Options {
+		kws = append(kws, o.Keyword)
+	}
+	if want := []string{"PageSize", "InputSlot", "Duplex", "StapleLocation", "JCLEconomode"}; !reflect.DeepEqual(kws, want) {
+		t.Errorf("got options %q, want %q", kws, want)
+	}
+	o := p.Option("Duplex")
+	want := &Option{
+		Keyword: "Duplex",
+		Text:    "2-Sided Printing",
+		Default: "None",
+		Section: "AnySetup",
+		Order:   5,
+		Choices: []Choice{
+			{"None", "Off", "\n  <</Duplex false>> setpagedevice"},
+			{"DuplexNoTumble", "Long Edge", "\n  <</Duplex true /Tumble false>>\n  setpagedevice"},
+			{"DuplexTumble", "Short Edge", "<</Duplex true /Tumble true>> setpagedevice"},
+		},
+	}
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("got %+v\nwant %+v", o, want)
+	}
+	if f, ok := p.Feature("InputSlot", "Tray2"); !ok || f.Code != "<</MediaPosition 0>> setpagedevice" {
+		t.Errorf("InputSlot Tray2: got %+v, %v", f, ok)
+	}
+	if _, ok := p.Feature("InputSlot", "Tray9"); ok {
+		t.Errorf("InputSlot Tray9 must not exist")
+	}
+	if p.Option("PaperDimension") != nil {
+		t.Errorf("PaperDimension is not user interface option")
+	}
+}
+
+func TestParsePPDErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"*FormatVersion: \"4.3\"\n",
+		"*PPD-Adobe: \"4.3\"\n*Duplex None/Off: \"unterminated\n",
+	} {
+		if _, err := ParsePPD(strings.NewReader(s)); err != ErrPPD {
+			t.Errorf("%q: got %v, want %v", s, err, ErrPPD)
+		}
+	}
+}
+
+func TestPPDFeatures(t *testing.T) {
+	p, err := ParsePPD(strings.NewReader(testPPD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := p.Features(&ticket.Ticket{
+		Copies:     2,
+		Duplex:     ticket.TwoSidedShortEdge,
+		Media:      media.A4,
+		Tray:       "tray-2",
+		Resolution: 600,
+		Color:      ticket.Monochrome,
+		Staple:     true,
+	})
+	var got []string
+	for _, f := range fs {
+		got = append(got, f.Keyword+" "+f.Choice)
+	}
+	// Resolution and color are not supported by the printer.
+	want := []string{
+		"Duplex DuplexTumble",
+		"PageSize A4",
+		"InputSlot Tray2",
+		"StapleLocation UpperLeft",
+		"NumCopies 2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+	if fs := p.Features(nil); len(fs) != 0 {
+		t.Errorf("nil ticket: got %v, want no features", fs)
+	}
+	fs = p.Features(&ticket.Ticket{Tray: "manual", Media: media.Size{Width: 10000, Height: 15000}})
+	if len(fs) != 1 || fs[0].Choice != "ManualFeed" {
+		t.Errorf("got %+v, want manual feed only", fs)
+	}
+}
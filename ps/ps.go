@@ -0,0 +1,443 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ps sets job options, like duplex and paper size, in
+// PostScript print jobs.
+//
+// Options are set by setpagedevice code that Filter inserts into
+// document setup section, as described by Adobe Document Structuring
+// Conventions (DSC). The code comes either from Features, that only
+// uses standard setpagedevice keys, or from printer PPD file:
+//
+//	ppd, err := ps.ParsePPD(f)
+//	...
+//	_, err = ps.Filter(printer, job, ppd.Features(t))
+package ps
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexbrainman/printer/ticket"
+)
+
+var ErrNoPostScript = errors.New("ps: job has no PostScript document")
+
+// Feature is PostScript code that sets single job option.
+type Feature struct {
+	Keyword string // PPD main keyword without '*', like "Duplex"
+	Choice  string // PPD option keyword, like "DuplexTumble"
+	Code    string // PostScript code
+	NonPPD  bool   // Keyword is not PPD keyword, like "NumCopies"
+}
+
+// String returns f as DSC feature. The code is protected by stopped,
+// so printers that do not support the feature still print the job.
+func (f Feature) String() string {
+	return f.text("\n")
+}
+
+func (f Feature) text(eol string) string {
+	begin, end := "%%BeginFeature: *", "%%EndFeature"
+	if f.NonPPD {
+		begin, end = "%%BeginNonPPDFeature: ", "%%EndNonPPDFeature"
+	}
+	code := strings.TrimRight(f.Code, "\r\n")
+	code = strings.Replace(code, "\r\n", "\n", -1)
+	code = strings.Replace(code, "\n", eol, -1)
+	return "[{" + eol +
+		begin + f.Keyword + " " + f.Choice + eol +
+		code + eol +
+		end + eol +
+		"} stopped cleartomark" + eol
+}
+
+// pageSizes maps PWG media names to PPD PageSize names.
+var pageSizes = map[string]string{
+	"iso_a3_297x420mm":         "A3",
+	"iso_a4_210x297mm":         "A4",
+	"iso_a5_148x210mm":         "A5",
+	"iso_a6_105x148mm":         "A6",
+	"jis_b4_257x364mm":         "B4",
+	"jis_b5_182x257mm":         "B5",
+	"iso_c5_162x229mm":         "EnvC5",
+	"iso_dl_110x220mm":         "EnvDL",
+	"na_letter_8.5x11in":       "Letter",
+	"na_legal_8.5x14in":        "Legal",
+	"na_ledger_11x17in":        "Tabloid",
+	"na_executive_7.25x10.5in": "Executive",
+	"na_invoice_5.5x8.5in":     "Statement",
+	"na_number-10_4.125x9.5in": "Env10",
+	"na_monarch_3.875x7.5in":   "EnvMonarch",
+}
+
+// points converts length from 1/100 mm into PostScript points.
+func points(v int) int {
+	return (v*72 + 1270) / 2540
+}
+
+// Features returns features that set options t. Only options that
+// have standard setpagedevice keys are set: copies, collate, duplex,
+// paper size, manual feed, resolution and color. Use PPD.Features to
+// select trays and stapling. t can be nil.
+func Features(t *ticket.Ticket) []Feature {
+	if t == nil {
+		t = &ticket.Ticket{}
+	}
+	var fs []Feature
+	if t.Media.Width > 0 && t.Media.Height > 0 {
+		w, h := points(t.Media.Width), points(t.Media.Height)
+		name, ok := pageSizes[t.Media.Name]
+		if !ok {
+			name = fmt.Sprintf("Custom.%dx%d", w, h)
+		}
+		fs = append(fs, Feature{
+			Keyword: "PageSize",
+			Choice:  name,
+			Code:    fmt.Sprintf("<</PageSize [%d %d] /ImagingBBox null>> setpagedevice", w, h),
+		})
+	}
+	if t.Tray == "manual" {
+		fs = append(fs, Feature{
+			Keyword: "ManualFeed",
+			Choice:  "True",
+			Code:    "<</ManualFeed true>> setpagedevice",
+		})
+	}
+	switch t.Duplex {
+	case ticket.OneSided:
+		fs = append(fs, Feature{"Duplex", "None", "<</Duplex false>> setpagedevice", false})
+	case ticket.TwoSidedLongEdge:
+		fs = append(fs, Feature{"Duplex", "DuplexNoTumble", "<</Duplex true /Tumble false>> setpagedevice", false})
+	case ticket.TwoSidedShortEdge:
+		fs = append(fs, Feature{"Duplex", "DuplexTumble", "<</Duplex true /Tumble true>> setpagedevice", false})
+	}
+	if t.Resolution > 0 {
+		fs = append(fs, Feature{
+			Keyword: "Resolution",
+			Choice:  fmt.Sprintf("%ddpi", t.Resolution),
+			Code:    fmt.Sprintf("<</HWResolution [%d %d]>> setpagedevice", t.Resolution, t.Resolution),
+		})
+	}
+	switch t.Color {
+	case ticket.Monochrome:
+		fs = append(fs, Feature{"ColorModel", "Gray", "<</ProcessColorModel /DeviceGray>> setpagedevice", false})
+	case ticket.Color:
+		fs = append(fs, Feature{"ColorModel", "CMYK", "<</ProcessColorModel /DeviceCMYK>> setpagedevice", false})
+	}
+	if t.Collate {
+		fs = append(fs, Feature{"Collate", "True", "<</Collate true>> setpagedevice", false})
+	}
+	return append(fs, copies(t)...)
+}
+
+// copies returns feature that sets number of copies. PPD files do not
+// describe copies, so it is the same for all printers.
+func copies(t *ticket.Ticket) []Feature {
+	if t.Copies < 1 {
+		return nil
+	}
+	return []Feature{{
+		Keyword: "NumCopies",
+		Choice:  fmt.Sprint(t.Copies),
+		Code:    fmt.Sprintf("<</NumCopies %d>> setpagedevice", t.Copies),
+		NonPPD:  true,
+	}}
+}
+
+// Header is DSC header of PostScript document.
+type Header struct {
+	// Version is DSC version, like "3.0". It is empty if
+	// document does not conform to DSC.
+	Version string
+	// Comments are header comments, like "Title" or "Pages",
+	// and their values.
+	Comments map[string]string
+}
+
+// Filter copies PostScript document from r to w and inserts features
+// into document setup section. The section is created, if document
+// does not have one. Features already present in the section for the
+// same keywords are removed. Features are inserted after the first line
+// of documents that do not conform to DSC, and before the first line of
+// documents without "%!" line, that PJL job header switched to PostScript.
+// PJL commands before the document are copied as is. Other documents,
+// like PCL or PDF, are copied unchanged. Filter returns DSC header of
+// the document, and ErrNoPostScript, if features are not inserted,
+// because r has no PostScript document.
+func Filter(w io.Writer, r io.Reader, features []Feature) (*Header, error) {
+	f := &filter{
+		w:        bufio.NewWriter(w),
+		features: features,
+		keywords: make(map[string]bool),
+		h:        &Header{Comments: make(map[string]string)},
+		eol:      "\n",
+	}
+	for _, ft := range features {
+		f.keywords[ft.Keyword] = true
+	}
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			f.line(line)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return f.h, err
+		}
+	}
+	f.end()
+	if f.err != nil {
+		return f.h, f.err
+	}
+	if err := f.w.Flush(); err != nil {
+		return f.h, err
+	}
+	if (f.state == stStart || f.state == stOther) && len(features) > 0 {
+		return f.h, ErrNoPostScript
+	}
+	return f.h, nil
+}
+
+// Filter states.
+const (
+	stStart    = iota // before the first line
+	stHeader          // in header comments
+	stTop             // between sections before setup
+	stDefaults        // in defaults section
+	stProlog          // in prolog section
+	stSetup           // in document setup section
+	stDone            // features are written
+	stOther           // document is not PostScript
+)
+
+type filter struct {
+	w        *bufio.Writer
+	features []Feature
+	keywords map[string]bool
+	h        *Header
+	eol      string
+	state    int
+	pjlPS    bool   // PJL job entered PostScript language
+	depth    int    // nesting level of embedded documents
+	skip     bool   // skipping feature replaced by ours
+	wrapped  bool   // skipped feature is in stopped context
+	unwrap   bool   // skip the end of stopped context
+	open     string // start of stopped context waiting for the next line
+	last     string // last header comment
+	nl       bool   // output ends with new line
+	err      error
+}
+
+func (f *filter) write(s string) {
+	if f.err != nil {
+		return
+	}
+	_, f.err = f.w.WriteString(s)
+	f.nl = strings.HasSuffix(s, "\n")
+}
+
+func (f *filter) writeFeatures() {
+	for _, ft := range f.features {
+		f.write(ft.text(f.eol))
+	}
+}
+
+// writeSetup writes features in new setup section.
+func (f *filter) writeSetup() {
+	if len(f.features) == 0 {
+		return
+	}
+	f.write("%%BeginSetup" + f.eol)
+	f.writeFeatures()
+	f.write("%%EndSetup" + f.eol)
+}
+
+func (f *filter) line(b []byte) {
+	line := string(b)
+	s := strings.TrimRight(line, "\r\n")
+	switch f.state {
+	case stStart:
+		p := strings.TrimLeft(s, "\x04")
+		if strings.HasPrefix(p, "\x1b%-12345X") || strings.HasPrefix(p, "@PJL") {
+			cmd := strings.ToUpper(strings.Join(strings.Fields(p), ""))
+			if strings.Contains(cmd, "@PJLENTERLANGUAGE=") {
+				f.pjlPS = strings.Contains(cmd, "@PJLENTERLANGUAGE=POSTSCRIPT")
+			}
+			f.write(line)
+			return
+		}
+		// Document line ending can differ from PJL one.
+		if strings.HasSuffix(line, "\r\n") {
+			f.eol = "\r\n"
+		}
+		if !strings.HasPrefix(p, "%!") {
+			if f.pjlPS {
+				f.writeFeatures()
+				f.state = stDone
+			} else {
+				f.state = stOther
+			}
+			f.write(line)
+			return
+		}
+		f.write(line)
+		if strings.HasPrefix(p, "%!PS-Adobe-") {
+			v := p[len("%!PS-Adobe-"):]
+			if i := strings.IndexAny(v, " \t"); i >= 0 {
+				v = v[:i]
+			}
+			f.h.Version = v
+			f.state = stHeader
+			return
+		}
+		f.writeFeatures()
+		f.state = stDone
+	case stHeader:
+		switch {
+		case s == "%%EndComments":
+			f.write(line)
+			f.state = stTop
+		case strings.HasPrefix(s, "%%+"):
+			if f.last != "" {
+				f.h.Comments[f.last] += " " + strings.TrimSpace(s[3:])
+			}
+			f.write(line)
+		case strings.HasPrefix(s, "%%") && !isSection(s):
+			name, value := s[2:], ""
+			if i := strings.IndexByte(name, ':'); i >= 0 {
+				name, value = name[:i], strings.TrimSpace(name[i+1:])
+			}
+			// The first comment wins, as required by DSC.
+			if _, ok := f.h.Comments[name]; !ok {
+				f.h.Comments[name] = value
+			}
+			f.last = name
+			f.write(line)
+		default:
+			f.state = stTop
+			f.line(b)
+		}
+	case stTop:
+		switch {
+		case strings.TrimSpace(s) == "":
+		case s == "%%BeginDefaults":
+			f.state = stDefaults
+		case s == "%%BeginProlog":
+			f.state = stProlog
+		case s == "%%BeginSetup":
+			f.state = stSetup
+		default:
+			f.writeSetup()
+			f.state = stDone
+		}
+		f.write(line)
+	case stDefaults:
+		if s == "%%EndDefaults" {
+			f.state = stTop
+		}
+		f.write(line)
+	case stProlog:
+		if f.nested(s) == 0 && s == "%%EndProlog" {
+			f.state = stTop
+		}
+		f.write(line)
+	case stSetup:
+		if f.skip {
+			if s == "%%EndFeature" || s == "%%EndNonPPDFeature" {
+				f.skip = false
+				f.unwrap = f.wrapped
+			}
+			return
+		}
+		if f.unwrap {
+			f.unwrap = false
+			if s == "} stopped cleartomark" {
+				return
+			}
+		}
+		open := f.open
+		f.open = ""
+		if f.depth == 0 && (f.replaced(s, "%%BeginFeature:") || f.replaced(s, "%%BeginNonPPDFeature:")) {
+			// Replaced feature is removed together with
+			// stopped context around it.
+			f.skip = true
+			f.wrapped = open != ""
+			return
+		}
+		if open != "" {
+			f.write(open)
+		}
+		if f.nested(s) == 0 {
+			switch {
+			case s == "[{":
+				// Stopped context is written, when the next
+				// line shows that it is not replaced feature.
+				f.open = line
+				return
+			case s == "%%EndSetup":
+				f.writeFeatures()
+				f.state = stDone
+			case f.replaced(s, "%%IncludeFeature:"):
+				return
+			}
+		}
+		f.write(line)
+	case stDone, stOther:
+		f.write(line)
+	}
+}
+
+// end finishes document that ended before features were written.
+func (f *filter) end() {
+	if f.open != "" {
+		f.write(f.open)
+	}
+	if f.state != stStart && f.state != stDone && f.state != stOther && len(f.features) > 0 && !f.nl {
+		f.write(f.eol)
+	}
+	switch f.state {
+	case stHeader, stTop, stDefaults, stProlog:
+		f.writeSetup()
+	case stSetup:
+		f.writeFeatures()
+	}
+}
+
+// isSection reports whether comment s starts document section,
+// so it also ends header without %%EndComments.
+func isSection(s string) bool {
+	return strings.HasPrefix(s, "%%Begin") ||
+		strings.HasPrefix(s, "%%Page:") ||
+		s == "%%Trailer" || s == "%%EOF"
+}
+
+// nested tracks embedded documents and returns their nesting level.
+func (f *filter) nested(s string) int {
+	switch {
+	case strings.HasPrefix(s, "%%BeginDocument"):
+		f.depth++
+		return f.depth
+	case s == "%%EndDocument" && f.depth > 0:
+		f.depth--
+		return f.depth + 1
+	}
+	return f.depth
+}
+
+// replaced reports whether line s is feature comment, that starts
+// with prefix, for one of our keywords.
+func (f *filter) replaced(s, prefix string) bool {
+	if !strings.HasPrefix(s, prefix) {
+		return false
+	}
+	fields := strings.Fields(s[len(prefix):])
+	return len(fields) > 0 && f.keywords[strings.TrimPrefix(fields[0], "*")]
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ps
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+	"github.com/alexbrainman/printer/ticket"
+)
+
+func TestFeatures(t *testing.T) {
+	fs := Features(&ticket.Ticket{
+		Copies:     2,
+		Collate:    true,
+		Duplex:     ticket.TwoSidedLongEdge,
+		Media:      media.A4,
+		Tray:       "manual",
+		Resolution: 600,
+		Color:      ticket.Monochrome,
+		Staple:     true,
+	})
+	var got []string
+	for _, f := range fs {
+		got = append(got, f.Keyword+" "+f.Choice+": "+f.Code)
+	}
+	want := []string{
+		"PageSize A4: <</PageSize [595 842] /ImagingBBox null>> setpagedevice",
+		"ManualFeed True: <</ManualFeed true>> setpagedevice",
+		"Duplex DuplexNoTumble: <</Duplex true /Tumble false>> setpagedevice",
+		"Resolution 600dpi: <</HWResolution [600 600]>> setpagedevice",
+		"ColorModel Gray: <</ProcessColorModel /DeviceGray>> setpagedevice",
+		"Collate True: <</Collate true>> setpagedevice",
+		"NumCopies 2: <</NumCopies 2>> setpagedevice",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+	if !fs[len(fs)-1].NonPPD {
+		t.Errorf("NumCopies must be non-PPD feature")
+	}
+	if fs := Features(&ticket.Ticket{}); len(fs) != 0 {
+		t.Errorf("default ticket: got %v, want no features", fs)
+	}
+	if fs := Features(nil); len(fs) != 0 {
+		t.Errorf("nil ticket: got %v, want no features", fs)
+	}
+	custom := Features(&ticket.Ticket{Media: media.Size{Width: 10000, Height: 15000}})
+	if want := "Custom.283x425"; custom[0].Choice != want {
+		t.Errorf("custom size: got %q, want %q", custom[0].Choice, want)
+	}
+}
+
+func TestFeatureString(t *testing.T) {
+	f := Feature{Keyword: "Duplex", Choice: "None", Code: "<</Duplex false>> setpagedevice\n"}
+	want := "[{\n%%BeginFeature: *Duplex None\n<</Duplex false>> setpagedevice\n%%EndFeature\n} stopped cleartomark\n"
+	if got := f.String(); got != want {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+	f = Feature{Keyword: "NumCopies", Choice: "3", Code: "<</NumCopies 3>> setpagedevice", NonPPD: true}
+	want = "[{\n%%BeginNonPPDFeature: NumCopies 3\n<</NumCopies 3>> setpagedevice\n%%EndNonPPDFeature\n} stopped cleartomark\n"
+	if got := f.String(); got != want {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+}
+
+var testFeatures = []Feature{
+	{Keyword: "Duplex", Choice: "DuplexTumble", Code: "DUPLEX"},
+	{Keyword: "NumCopies", Choice: "2", Code: "COPIES", NonPPD: true},
+}
+
+// injected returns testFeatures as inserted by Filter.
+func injected(eol string) string {
+	var s string
+	for _, f := range testFeatures {
+		s += f.text(eol)
+	}
+	return s
+}
+
+func TestFilter(t *testing.T) {
+	fs := injected("\n")
+	tests := []struct {
+		name string
+		in   string
+		want string
+		err  error
+	}{
+		{
+			name: "setup",
+			in: "%!PS-Adobe-3.0\n%%Title: test\n%%EndComments\n" +
+				"%%BeginProlog\n/x 1 def\n%%EndProlog\n" +
+				"%%BeginSetup\n" +
+				"[{\n%%BeginFeature: *Duplex None\n<</Duplex false>> setpagedevice\n%%EndFeature\n} stopped cleartomark\n" +
+				"%%IncludeFeature: *Duplex None\n" +
+				"[{\n%%BeginFeature: *InputSlot Tray1\nTRAY1\n%%EndFeature\n} stopped cleartomark\n" +
+				"[{\n[{\n/y 2 def\n} stopped cleartomark\n" +
+				"%%BeginNonPPDFeature: NumCopies 5\n<</NumCopies 5>> setpagedevice\n%%EndNonPPDFeature\n" +
+				"} stopped cleartomark\n" +
+				"%%EndSetup\n%%Page: 1 1\nshowpage\n%%EOF\n",
+			want: "%!PS-Adobe-3.0\n%%Title: test\n%%EndComments\n" +
+				"%%BeginProlog\n/x 1 def\n%%EndProlog\n" +
+				"%%BeginSetup\n" +
+				"[{\n%%BeginFeature: *InputSlot Tray1\nTRAY1\n%%EndFeature\n} stopped cleartomark\n" +
+				"[{\n[{\n/y 2 def\n} stopped cleartomark\n" +
+				"} stopped cleartomark\n" +
+				fs + "%%EndSetup\n%%Page: 1 1\nshowpage\n%%EOF\n",
+		},
+		{
+			name: "no setup",
+			in: "%!PS-Adobe-3.0\n%%Pages: 1\n%%EndComments\n" +
+				"%%BeginProlog\n%%BeginDocument: logo.eps\n%%EndProlog\n%%EndDocument\n%%EndProlog\n\n" +
+				"%%Page: 1 1\nshowpage\n",
+			want: "%!PS-Adobe-3.0\n%%Pages: 1\n%%EndComments\n" +
+				"%%BeginProlog\n%%BeginDocument: logo.eps\n%%EndProlog\n%%EndDocument\n%%EndProlog\n\n" +
+				"%%BeginSetup\n" + fs + "%%EndSetup\n" +
+				"%%Page: 1 1\nshowpage\n",
+		},
+		{
+			name: "no end comments",
+			in:   "%!PS-Adobe-2.0\n%%Creator: app\n%%+ v2\n/Times findfont\nshowpage\n",
+			want: "%!PS-Adobe-2.0\n%%Creator: app\n%%+ v2\n" +
+				"%%BeginSetup\n" + fs + "%%EndSetup\n" +
+				"/Times findfont\nshowpage\n",
+		},
+		{
+			name: "header only",
+			in:   "%!PS-Adobe-3.0\n%%EndComments",
+			want: "%!PS-Adobe-3.0\n%%EndComments\n%%BeginSetup\n" + fs + "%%EndSetup\n",
+		},
+		{
+			name: "not conforming",
+			in:   "%!\n/Times findfont\nshowpage\n",
+			want: "%!\n" + fs + "/Times findfont\nshowpage\n",
+		},
+		{
+			name: "pjl",
+			in:   "\x1b%-12345X@PJL JOB\n@PJL ENTER LANGUAGE = POSTSCRIPT\n\x04%!PS-Adobe-3.0\n%%EndComments\n%%Page: 1 1\n",
+			want: "\x1b%-12345X@PJL JOB\n@PJL ENTER LANGUAGE = POSTSCRIPT\n\x04%!PS-Adobe-3.0\n%%EndComments\n" +
+				"%%BeginSetup\n" + fs + "%%EndSetup\n%%Page: 1 1\n",
+		},
+		{
+			name: "pjl crlf",
+			in:   "\x1b%-12345X@PJL JOB\r\n@PJL ENTER LANGUAGE = POSTSCRIPT\r\n%!PS-Adobe-3.0\n%%EndComments\n%%Page: 1 1\n",
+			want: "\x1b%-12345X@PJL JOB\r\n@PJL ENTER LANGUAGE = POSTSCRIPT\r\n%!PS-Adobe-3.0\n%%EndComments\n" +
+				"%%BeginSetup\n" + fs + "%%EndSetup\n%%Page: 1 1\n",
+		},
+		{
+			name: "pjl postscript without header",
+			in:   "\x1b%-12345X@PJL JOB\r\n@PJL ENTER LANGUAGE=POSTSCRIPT\r\n/Times findfont\r\nshowpage\r\n",
+			want: "\x1b%-12345X@PJL JOB\r\n@PJL ENTER LANGUAGE=POSTSCRIPT\r\n" + injected("\r\n") + "/Times findfont\r\nshowpage\r\n",
+		},
+		{
+			name: "pjl pcl",
+			in:   "\x1b%-12345X@PJL JOB\r\n@PJL ENTER LANGUAGE=PCL\r\n\x1bE\x1b&l26A%!text\n\x1bE\x1b%-12345X",
+			want: "\x1b%-12345X@PJL JOB\r\n@PJL ENTER LANGUAGE=PCL\r\n\x1bE\x1b&l26A%!text\n\x1bE\x1b%-12345X",
+			err:  ErrNoPostScript,
+		},
+		{
+			name: "pcl",
+			in:   "\x1bE\x1b&l1S\r\ntext\r\n\x1bE",
+			want: "\x1bE\x1b&l1S\r\ntext\r\n\x1bE",
+			err:  ErrNoPostScript,
+		},
+		{
+			name: "pdf",
+			in:   "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n1 0 obj\n<<>>\nendobj\n%%EOF\n",
+			want: "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n1 0 obj\n<<>>\nendobj\n%%EOF\n",
+			err:  ErrNoPostScript,
+		},
+		{
+			name: "setup ends with stopped context",
+			in:   "%!PS-Adobe-3.0\n%%BeginSetup\n[{\n",
+			want: "%!PS-Adobe-3.0\n%%BeginSetup\n[{\n" + fs,
+		},
+		{
+			name: "crlf",
+			in:   "%!PS-Adobe-3.0\r\n%%EndComments\r\n%%BeginSetup\r\n%%EndSetup\r\nshowpage\r\n",
+			want: "%!PS-Adobe-3.0\r\n%%EndComments\r\n%%BeginSetup\r\n" + injected("\r\n") + "%%EndSetup\r\nshowpage\r\n",
+		},
+		{
+			name: "empty",
+			in:   "",
+			want: "",
+			err:  ErrNoPostScript,
+		},
+		{
+			name: "pjl only",
+			in:   "\x1b%-12345X@PJL INFO STATUS\r\n\x1b%-12345X",
+			want: "\x1b%-12345X@PJL INFO STATUS\r\n\x1b%-12345X",
+			err:  ErrNoPostScript,
+		},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if _, err := Filter(&b, strings.NewReader(tt.in), testFeatures); err != tt.err {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFilterHeader(t *testing.T) {
+	in := "%!PS-Adobe-3.0 EPSF-3.0\n%%Title: report\n%%DocumentMedia: A4 595 842 0 () ()\n%%+ Letter 612 792 0 () ()\n" +
+		"%%Pages: (atend)\n%%EndComments\nshowpage\n%%Trailer\n%%Pages: 1\n"
+	var b bytes.Buffer
+	h, err := Filter(&b, strings.NewReader(in), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != in {
+		t.Errorf("document without features changed:\n%q", b.String())
+	}
+	want := &Header{
+		Version: "3.0",
+		Comments: map[string]string{
+			"Title":         "report",
+			"DocumentMedia": "A4 595 842 0 () () Letter 612 792 0 () ()",
+			"Pages":         "(atend)",
+		},
+	}
+	if !reflect.DeepEqual(h, want) {
+		t.Errorf("got %+v\nwant %+v", h, want)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raster
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+// PWG Raster (PWG 5102.4) stream starts with sync word, and every
+// page starts with page header of pwgHeaderSize bytes.
+const (
+	pwgSync       = "RaS2"
+	pwgHeaderSize = 1796
+)
+
+// Offsets of PWG Raster page header fields. String fields are
+// 64 bytes long, and integer fields are big endian uint32.
+const (
+	pwgMediaClass         = 0
+	pwgMediaType          = 128
+	pwgDuplex             = 272
+	pwgHWResolution       = 276
+	pwgMediaPosition      = 324
+	pwgNumCopies          = 340
+	pwgPageSize           = 352
+	pwgTumble             = 368
+	pwgWidth              = 372
+	pwgHeight             = 376
+	pwgBitsPerColor       = 384
+	pwgBitsPerPixel       = 388
+	pwgBytesPerLine       = 392
+	pwgColorSpace         = 400
+	pwgNumColors          = 420
+	pwgCrossFeedTransform = 456
+	pwgFeedTransform      = 460
+	pwgPrintQuality       = 484
+	pwgPageSizeName       = 1732
+)
+
+// PWG Raster color space values.
+const (
+	pwgBlack = 3
+	pwgSGray = 18
+	pwgSRGB  = 19
+)
+
+// PWGWriter writes pages in PWG Raster format.
+type PWGWriter struct {
+	w       *bufio.Writer
+	started bool
+}
+
+// NewPWGWriter returns new PWGWriter that writes to w.
+func NewPWGWriter(w io.Writer) *PWGWriter {
+	return &PWGWriter{w: bufio.NewWriter(w)}
+}
+
+func putString(b []byte, s string) {
+	// Strings are nul terminated.
+	if len(s) > 63 {
+		s = s[:63]
+	}
+	copy(b[:63], s)
+}
+
+func getString(b []byte) string {
+	b = b[:64]
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+// WritePage writes page p.
+func (pw *PWGWriter) WritePage(p *Page) error {
+	if err := p.check(); err != nil {
+		return err
+	}
+	if !pw.started {
+		pw.w.WriteString(pwgSync)
+		pw.started = true
+	}
+	var h [pwgHeaderSize]byte
+	put := func(off int, v int) {
+		binary.BigEndian.PutUint32(h[off:], uint32(v))
+	}
+	putBool := func(off int, v bool) {
+		if v {
+			put(off, 1)
+		}
+	}
+	putString(h[pwgMediaClass:], "PwgRaster")
+	putString(h[pwgMediaType:], p.MediaType)
+	putBool(pwgDuplex, p.Duplex)
+	put(pwgHWResolution, p.Resolution)
+	put(pwgHWResolution+4, p.Resolution)
+	put(pwgMediaPosition, p.MediaPosition)
+	put(pwgNumCopies, p.Copies)
+	w, ht := p.pageSize()
+	put(pwgPageSize, w)
+	put(pwgPageSize+4, ht)
+	putBool(pwgTumble, p.Tumble)
+	put(pwgWidth, p.Width)
+	put(pwgHeight, p.Height)
+	bpp := p.ColorSpace.bitsPerPixel()
+	switch p.ColorSpace {
+	case RGB:
+		put(pwgBitsPerColor, 8)
+		put(pwgColorSpace, pwgSRGB)
+		put(pwgNumColors, 3)
+	case Black:
+		put(pwgBitsPerColor, 1)
+		put(pwgColorSpace, pwgBlack)
+		put(pwgNumColors, 1)
+	default:
+		put(pwgBitsPerColor, 8)
+		put(pwgColorSpace, pwgSGray)
+		put(pwgNumColors, 1)
+	}
+	put(pwgBitsPerPixel, bpp)
+	put(pwgBytesPerLine, p.Stride)
+	put(pwgCrossFeedTransform, 1)
+	put(pwgFeedTransform, 1)
+	put(pwgPrintQuality, int(p.Quality))
+	putString(h[pwgPageSizeName:], p.Media.Name)
+	pw.w.Write(h[:])
+	encodeLines(pw.w, p)
+	return pw.w.Flush()
+}
+
+// PWGReader reads pages in PWG Raster format.
+type PWGReader struct {
+	r       *bufio.Reader
+	started bool
+}
+
+// NewPWGReader returns new PWGReader that reads from r.
+func NewPWGReader(r io.Reader) *PWGReader {
+	return &PWGReader{r: bufio.NewReader(r)}
+}
+
+// ReadPage reads next page. It returns io.EOF, when there
+// are no more pages.
+func (pr *PWGReader) ReadPage() (*Page, error) {
+	if !pr.started {
+		var sync [4]byte
+		if _, err := io.ReadFull(pr.r, sync[:]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if string(sync[:]) != pwgSync {
+			return nil, ErrFormat
+		}
+		pr.started = true
+	}
+	var h [pwgHeaderSize]byte
+	// ReadFull returns io.EOF, if there are no more pages.
+	if _, err := io.ReadFull(pr.r, h[:]); err != nil {
+		return nil, err
+	}
+	get := func(off int) int {
+		return int(binary.BigEndian.Uint32(h[off:]))
+	}
+	if getString(h[pwgMediaClass:]) != "PwgRaster" {
+		return nil, ErrFormat
+	}
+	var cs ColorSpace
+	switch {
+	case get(pwgColorSpace) == pwgSGray && get(pwgBitsPerPixel) == 8:
+		cs = Gray
+	case get(pwgColorSpace) == pwgSRGB && get(pwgBitsPerPixel) == 24:
+		cs = RGB
+	case get(pwgColorSpace) == pwgBlack && get(pwgBitsPerPixel) == 1:
+		cs = Black
+	default:
+		return nil, ErrColorSpace
+	}
+	p := &Page{
+		ColorSpace:    cs,
+		Width:         get(pwgWidth),
+		Height:        get(pwgHeight),
+		Resolution:    get(pwgHWResolution),
+		MediaType:     getString(h[pwgMediaType:]),
+		MediaPosition: get(pwgMediaPosition),
+		Duplex:        get(pwgDuplex) != 0,
+		Tumble:        get(pwgTumble) != 0,
+		Copies:        get(pwgNumCopies),
+		Quality:       Quality(get(pwgPrintQuality)),
+		Stride:        get(pwgBytesPerLine),
+	}
+	if m, ok := media.Lookup(getString(h[pwgPageSizeName:])); ok {
+		p.Media = m
+	}
+	if err := p.checkSize(); err != nil {
+		return nil, err
+	}
+	p.Pix = make([]byte, p.Stride*p.Height)
+	fill := byte(0xff)
+	if cs == Black {
+		fill = 0
+	}
+	if err := decodeLines(pr.r, p, fill); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
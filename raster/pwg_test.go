@@ -0,0 +1,122 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raster
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+func TestPWG(t *testing.T) {
+	rand.Seed(3)
+	p1 := randomPage(RGB, 100, 40)
+	p1.Media = media.A4
+	p1.MediaType = "stationery"
+	p1.Duplex = true
+	p1.Copies = 2
+	p1.Quality = QualityHigh
+	p2 := randomPage(Black, 33, 20)
+	p2.Resolution = 600
+	p2.Duplex = true
+	p2.Tumble = true
+	p2.MediaPosition = 2
+	p3 := randomPage(Gray, 17, 5)
+	pages := []*Page{p1, p2, p3}
+
+	var b bytes.Buffer
+	w := NewPWGWriter(&b)
+	for _, p := range pages {
+		if err := w.WritePage(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := b.Bytes()
+	if string(data[:4]) != "RaS2" {
+		t.Fatalf("stream starts with %q", data[:4])
+	}
+	h := data[4 : 4+pwgHeaderSize]
+	get := func(off int) uint32 { return binary.BigEndian.Uint32(h[off:]) }
+	fields := []struct {
+		name string
+		off  int
+		want uint32
+	}{
+		{"Duplex", 272, 1},
+		{"HWResolution[0]", 276, 300},
+		{"HWResolution[1]", 280, 300},
+		{"NumCopies", 340, 2},
+		{"PageSize[0]", 352, 595},
+		{"PageSize[1]", 356, 842},
+		{"Tumble", 368, 0},
+		{"cupsWidth", 372, 100},
+		{"cupsHeight", 376, 40},
+		{"cupsBitsPerColor", 384, 8},
+		{"cupsBitsPerPixel", 388, 24},
+		{"cupsBytesPerLine", 392, 300},
+		{"cupsColorSpace", 400, 19},
+		{"cupsNumColors", 420, 3},
+		{"CrossFeedTransform", 456, 1},
+		{"FeedTransform", 460, 1},
+		{"PrintQuality", 484, 5},
+	}
+	for _, f := range fields {
+		if v := get(f.off); v != f.want {
+			t.Errorf("%s is %d, want %d", f.name, v, f.want)
+		}
+	}
+	strs := []struct {
+		name string
+		off  int
+		want string
+	}{
+		{"MediaClass", 0, "PwgRaster"},
+		{"MediaType", 128, "stationery"},
+		{"PageSizeName", 1732, "iso_a4_210x297mm"},
+	}
+	for _, s := range strs {
+		if v := getString(h[s.off:]); v != s.want {
+			t.Errorf("%s is %q, want %q", s.name, v, s.want)
+		}
+	}
+
+	r := NewPWGReader(bytes.NewReader(data))
+	for i, want := range pages {
+		p, err := r.ReadPage()
+		if err != nil {
+			t.Fatalf("reading page %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(p, want) {
+			t.Errorf("page %d does not round trip:\n%+v\nwant\n%+v", i, p, want)
+		}
+	}
+	if _, err := r.ReadPage(); err != io.EOF {
+		t.Errorf("ReadPage after the last page returned %v, want io.EOF", err)
+	}
+
+	bad := [][]byte{
+		[]byte("RaS3"),
+		data[:100],
+		data[:len(data)-1],
+	}
+	for i, data := range bad {
+		r := NewPWGReader(bytes.NewReader(data))
+		var err error
+		for err == nil {
+			_, err = r.ReadPage()
+		}
+		if err == io.EOF {
+			t.Errorf("reading bad data %d returned io.EOF", i)
+		}
+	}
+	if err := NewPWGWriter(&b).WritePage(&Page{Width: 10, Height: 10}); err != ErrPageSize {
+		t.Errorf("writing empty page returned %v, want %v", err, ErrPageSize)
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package raster implements raster page formats accepted by
+// driverless printers: PWG Raster (image/pwg-raster), used by
+// IPP Everywhere printers, and Apple Raster (image/urf), used by
+// AirPrint printers. Both formats share the same page model and
+// line compression.
+package raster
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+
+	"github.com/alexbrainman/printer/bitmap"
+	"github.com/alexbrainman/printer/media"
+)
+
+// ColorSpace describes page pixel format.
+type ColorSpace int
+
+const (
+	// Gray is 8 bit sGray, where 0 is black and 255 is white.
+	// It is called W8 by Apple Raster.
+	Gray ColorSpace = iota
+	// RGB is 24 bit sRGB.
+	RGB
+	// Black is 1 bit black, where set bits are black. Leftmost pixel
+	// is stored in the most significant bit. It is only supported
+	// by PWG Raster.
+	Black
+)
+
+// bitsPerPixel returns number of bits used to store single pixel.
+func (cs ColorSpace) bitsPerPixel() int {
+	switch cs {
+	case RGB:
+		return 24
+	case Black:
+		return 1
+	default:
+		return 8
+	}
+}
+
+// Quality is print quality requested for the page.
+type Quality int
+
+const (
+	QualityDefault Quality = 0
+	QualityDraft   Quality = 3
+	QualityNormal  Quality = 4
+	QualityHigh    Quality = 5
+)
+
+var (
+	ErrFormat     = errors.New("raster: invalid raster data")
+	ErrColorSpace = errors.New("raster: color space is not supported")
+	ErrPageSize   = errors.New("raster: page pixel data does not match page size")
+)
+
+// Page is a single raster page.
+type Page struct {
+	ColorSpace    ColorSpace
+	Width, Height int // in pixels
+	Resolution    int // in dots per inch, the same in both directions
+
+	// Media is page media. If Media size is zero, page size is
+	// calculated from pixel size and resolution.
+	Media media.Size
+	// MediaType is PWG media type name, like "stationery".
+	// It is only used by PWG Raster.
+	MediaType     string
+	MediaPosition int  // input tray number; 0 selects the default
+	Duplex        bool // print on both sides of the sheet
+	Tumble        bool // flip duplex sheets on short edge
+	Copies        int  // number of copies; only used by PWG Raster
+	Quality       Quality
+
+	Stride int // bytes per line
+	Pix    []byte
+}
+
+// NewPage returns new white page of the given size.
+func NewPage(cs ColorSpace, width, height, resolution int) *Page {
+	p := &Page{
+		ColorSpace: cs,
+		Width:      width,
+		Height:     height,
+		Resolution: resolution,
+	}
+	p.Stride = (width*cs.bitsPerPixel() + 7) / 8
+	p.Pix = make([]byte, p.Stride*height)
+	if cs != Black {
+		for i := range p.Pix {
+			p.Pix[i] = 0xff
+		}
+	}
+	return p
+}
+
+// FromImage converts image m into page of color space cs. Black
+// pages are produced with Floyd-Steinberg dithering.
+func FromImage(m image.Image, cs ColorSpace, resolution int) *Page {
+	r := m.Bounds()
+	p := NewPage(cs, r.Dx(), r.Dy(), resolution)
+	switch cs {
+	case Black:
+		copy(p.Pix, bitmap.FromImage(m, bitmap.FloydSteinberg).Pix)
+	case RGB:
+		i := 0
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				cr, cg, cb, ca := m.At(x, y).RGBA()
+				// Colors are alpha-premultiplied, so adding
+				// missing alpha composes them over white.
+				w := 0xffff - ca
+				p.Pix[i+0] = uint8((cr + w) >> 8)
+				p.Pix[i+1] = uint8((cg + w) >> 8)
+				p.Pix[i+2] = uint8((cb + w) >> 8)
+				i += 3
+			}
+		}
+	default:
+		i := 0
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				cr, cg, cb, ca := m.At(x, y).RGBA()
+				w := 0xffff - ca
+				p.Pix[i] = uint8((19595*(cr+w) + 38470*(cg+w) + 7471*(cb+w) + 1<<15) >> 24)
+				i++
+			}
+		}
+	}
+	return p
+}
+
+// pageSize returns page size in points.
+func (p *Page) pageSize() (width, height int) {
+	if p.Media.Width != 0 && p.Media.Height != 0 {
+		w, h := p.Media.Points()
+		return int(w + 0.5), int(h + 0.5)
+	}
+	if p.Resolution <= 0 {
+		return 0, 0
+	}
+	return (p.Width*72 + p.Resolution/2) / p.Resolution, (p.Height*72 + p.Resolution/2) / p.Resolution
+}
+
+// check verifies that page pixel data matches page size.
+func (p *Page) check() error {
+	if p.Width <= 0 || p.Height <= 0 || p.Stride != (p.Width*p.ColorSpace.bitsPerPixel()+7)/8 || len(p.Pix) < p.Stride*p.Height {
+		return ErrPageSize
+	}
+	return nil
+}
+
+// maxSize limits page width and height read by decoders, to
+// avoid huge allocations for invalid data.
+const maxSize = 1 << 16
+
+// checkSize verifies page size read by decoder.
+func (p *Page) checkSize() error {
+	if p.Width <= 0 || p.Height <= 0 || p.Width > maxSize || p.Height > maxSize ||
+		p.Stride != (p.Width*p.ColorSpace.bitsPerPixel()+7)/8 {
+		return ErrFormat
+	}
+	return nil
+}
+
+// ColorModel implements image.Image interface.
+func (p *Page) ColorModel() color.Model {
+	if p.ColorSpace == RGB {
+		return color.RGBAModel
+	}
+	return color.GrayModel
+}
+
+// Bounds implements image.Image interface.
+func (p *Page) Bounds() image.Rectangle {
+	return image.Rect(0, 0, p.Width, p.Height)
+}
+
+// At implements image.Image interface.
+func (p *Page) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(p.Bounds())) {
+		return color.Gray{}
+	}
+	switch p.ColorSpace {
+	case RGB:
+		i := y*p.Stride + x*3
+		return color.RGBA{R: p.Pix[i], G: p.Pix[i+1], B: p.Pix[i+2], A: 0xff}
+	case Black:
+		if p.Pix[y*p.Stride+x/8]&(0x80>>uint(x%8)) != 0 {
+			return color.Gray{Y: 0}
+		}
+		return color.Gray{Y: 0xff}
+	default:
+		return color.Gray{Y: p.Pix[y*p.Stride+x]}
+	}
+}
+
+// line returns pixels of line y.
+func (p *Page) line(y int) []byte {
+	return p.Pix[y*p.Stride : (y+1)*p.Stride]
+}
+
+// pixelSize returns number of bytes in compressed pixel unit.
+func (p *Page) pixelSize() int {
+	if p.ColorSpace == Black {
+		return 1
+	}
+	return p.ColorSpace.bitsPerPixel() / 8
+}
+
+// encodeLines writes page p pixel data to w compressed with
+// the line compression used by PWG Raster and Apple Raster.
+//
+// Each group of identical lines starts with repeat count minus one.
+// It is followed by line pixels encoded as: count byte 0 - 127
+// followed by a pixel repeated count + 1 times, or count byte
+// 129 - 255 followed by 257 - count literal pixels.
+func encodeLines(w *bufio.Writer, p *Page) {
+	bpp := p.pixelSize()
+	for y := 0; y < p.Height; {
+		line := p.line(y)
+		n := 1
+		for y+n < p.Height && n < 256 && bytes.Equal(p.line(y+n), line) {
+			n++
+		}
+		w.WriteByte(byte(n - 1))
+		y += n
+
+		npix := len(line) / bpp
+		pix := func(i int) []byte { return line[i*bpp : (i+1)*bpp] }
+		for i := 0; i < npix; {
+			run := 1
+			for i+run < npix && run < 128 && bytes.Equal(pix(i+run), pix(i)) {
+				run++
+			}
+			if run > 1 || i+1 == npix {
+				w.WriteByte(byte(run - 1))
+				w.Write(pix(i))
+				i += run
+				continue
+			}
+			// Collect literal pixels until the next repeated pixel.
+			j := i + 1
+			for j < npix && j-i < 128 {
+				if j+1 < npix && bytes.Equal(pix(j), pix(j+1)) {
+					break
+				}
+				j++
+			}
+			if j-i == 1 {
+				w.WriteByte(0)
+				w.Write(pix(i))
+				i = j
+				continue
+			}
+			w.WriteByte(byte(257 - (j - i)))
+			w.Write(line[i*bpp : j*bpp])
+			i = j
+		}
+	}
+}
+
+// decodeLines reads compressed pixel data of page p from r.
+// fill is the byte used to fill the rest of the line, when
+// count byte is 128.
+func decodeLines(r *bufio.Reader, p *Page, fill byte) error {
+	bpp := p.pixelSize()
+	for y := 0; y < p.Height; {
+		b, err := r.ReadByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		repeat := int(b) + 1
+		line := p.line(y)
+		for i := 0; i < len(line); {
+			b, err := r.ReadByte()
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			switch {
+			case b == 128:
+				for ; i < len(line); i++ {
+					line[i] = fill
+				}
+			case b < 128:
+				n := (int(b) + 1) * bpp
+				if i+n > len(line) {
+					return ErrFormat
+				}
+				if _, err := io.ReadFull(r, line[i:i+bpp]); err != nil {
+					return unexpectedEOF(err)
+				}
+				for j := i + bpp; j < i+n; j += bpp {
+					copy(line[j:j+bpp], line[i:i+bpp])
+				}
+				i += n
+			default:
+				n := (257 - int(b)) * bpp
+				if i+n > len(line) {
+					return ErrFormat
+				}
+				if _, err := io.ReadFull(r, line[i:i+n]); err != nil {
+					return unexpectedEOF(err)
+				}
+				i += n
+			}
+		}
+		y++
+		for repeat--; repeat > 0 && y < p.Height; repeat-- {
+			copy(p.line(y), line)
+			y++
+		}
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raster
+
+import (
+	"bufio"
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+// randomPage returns page with random pixels that
+// contains repeated pixels and lines.
+func randomPage(cs ColorSpace, width, height int) *Page {
+	p := NewPage(cs, width, height, 300)
+	for y := 0; y < height; y++ {
+		line := p.line(y)
+		if y > 0 && rand.Intn(3) == 0 {
+			copy(line, p.line(y-1))
+			continue
+		}
+		for i := range line {
+			if i > 0 && rand.Intn(2) == 0 {
+				line[i] = line[i-1]
+				continue
+			}
+			line[i] = byte(rand.Intn(4))
+		}
+	}
+	return p
+}
+
+func TestLineCompression(t *testing.T) {
+	rand.Seed(1)
+	pages := []*Page{
+		NewPage(Gray, 1, 1, 300),
+		NewPage(RGB, 300, 300, 300),
+		NewPage(Black, 9, 600, 300),
+	}
+	for i := 0; i < 30; i++ {
+		cs := []ColorSpace{Gray, RGB, Black}[i%3]
+		pages = append(pages, randomPage(cs, 1+rand.Intn(300), 1+rand.Intn(30)))
+	}
+	for _, p := range pages {
+		var b bytes.Buffer
+		w := bufio.NewWriter(&b)
+		encodeLines(w, p)
+		w.Flush()
+		q := NewPage(p.ColorSpace, p.Width, p.Height, p.Resolution)
+		if err := decodeLines(bufio.NewReader(&b), q, 0xff); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p.Pix, q.Pix) {
+			t.Fatalf("%dx%d page of color space %d does not round trip", p.Width, p.Height, p.ColorSpace)
+		}
+		if b.Len() != 0 {
+			t.Fatalf("%d bytes left after decoding", b.Len())
+		}
+	}
+}
+
+func TestEncodeLines(t *testing.T) {
+	p := NewPage(RGB, 5, 3, 300)
+	copy(p.Pix, []byte{
+		1, 2, 3, 1, 2, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		1, 2, 3, 1, 2, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+	})
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+	encodeLines(w, p)
+	w.Flush()
+	want := []byte{
+		1,          // two identical lines
+		2, 1, 2, 3, // pixel repeated 3 times
+		255, 4, 5, 6, 7, 8, 9, // 2 literal pixels
+		0,                // single line
+		4, 255, 255, 255, // white line
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("encodeLines returned %v, want %v", b.Bytes(), want)
+	}
+}
+
+func TestDecodeFill(t *testing.T) {
+	p := NewPage(Gray, 4, 2, 300)
+	data := []byte{1, 0, 7, 128}
+	if err := decodeLines(bufio.NewReader(bytes.NewReader(data)), p, 0xff); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{7, 0xff, 0xff, 0xff, 7, 0xff, 0xff, 0xff}
+	if !bytes.Equal(p.Pix, want) {
+		t.Errorf("decoded %v, want %v", p.Pix, want)
+	}
+	for _, data := range [][]byte{{0, 4, 1}, {0, 0xfb, 1, 2, 3, 4, 5}, {0, 1}} {
+		err := decodeLines(bufio.NewReader(bytes.NewReader(data)), NewPage(Gray, 4, 1, 300), 0xff)
+		if err == nil {
+			t.Errorf("decoding %v succeeded", data)
+		}
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	m := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	m.Set(0, 0, color.NRGBA{R: 255, G: 128, B: 0, A: 255})
+	m.Set(1, 0, color.NRGBA{A: 0})
+	p := FromImage(m, RGB, 150)
+	if want := []byte{255, 128, 0, 255, 255, 255}; !bytes.Equal(p.Pix, want) {
+		t.Errorf("RGB page pixels are %v, want %v", p.Pix, want)
+	}
+	if c := p.At(0, 0); c != (color.RGBA{R: 255, G: 128, A: 255}) {
+		t.Errorf("At returned %v", c)
+	}
+	p = FromImage(m, Gray, 150)
+	if want := []byte{151, 255}; !bytes.Equal(p.Pix, want) {
+		t.Errorf("Gray page pixels are %v, want %v", p.Pix, want)
+	}
+	g := image.NewGray(image.Rect(0, 0, 10, 1))
+	g.Pix[9] = 255
+	p = FromImage(g, Black, 150)
+	if want := []byte{0xff, 0x80}; !bytes.Equal(p.Pix, want) {
+		t.Errorf("Black page pixels are %x, want %x", p.Pix, want)
+	}
+	if p.At(8, 0) != (color.Gray{Y: 0}) || p.At(9, 0) != (color.Gray{Y: 255}) {
+		t.Error("Black page At returned wrong colors")
+	}
+}
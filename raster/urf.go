@@ -0,0 +1,165 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raster
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// Apple Raster stream starts with magic string followed by page
+// count, and every page starts with page header of urfHeaderSize bytes.
+// All integers are big endian.
+const (
+	urfMagic      = "UNIRAST\x00"
+	urfHeaderSize = 32
+)
+
+// Offsets of Apple Raster page header fields. The first
+// fields are single bytes, and the rest are uint32.
+const (
+	urfBitsPerPixel  = 0
+	urfColorSpace    = 1
+	urfDuplex        = 2
+	urfQuality       = 3
+	urfMediaPosition = 5
+	urfWidth         = 12
+	urfHeight        = 16
+	urfResolution    = 20
+)
+
+// Apple Raster color space values.
+const (
+	urfSGray = 0
+	urfSRGB  = 1
+	urfW     = 4
+	urfRGB   = 5
+)
+
+// Apple Raster duplex values.
+const (
+	urfSimplex   = 1
+	urfShortEdge = 2
+	urfLongEdge  = 3
+)
+
+// URFWriter writes pages in Apple Raster format.
+type URFWriter struct {
+	w       *bufio.Writer
+	pages   int
+	started bool
+}
+
+// NewURFWriter returns new URFWriter that writes to w. Apple Raster
+// stream starts with number of pages, so pages must be known in advance.
+func NewURFWriter(w io.Writer, pages int) *URFWriter {
+	return &URFWriter{w: bufio.NewWriter(w), pages: pages}
+}
+
+// WritePage writes page p. Only Gray and RGB pages are supported.
+// Page Media, MediaType and Copies are ignored.
+func (uw *URFWriter) WritePage(p *Page) error {
+	if err := p.check(); err != nil {
+		return err
+	}
+	var h [urfHeaderSize]byte
+	switch p.ColorSpace {
+	case Gray:
+		h[urfColorSpace] = urfSGray
+	case RGB:
+		h[urfColorSpace] = urfSRGB
+	default:
+		return ErrColorSpace
+	}
+	if !uw.started {
+		uw.w.WriteString(urfMagic)
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], uint32(uw.pages))
+		uw.w.Write(n[:])
+		uw.started = true
+	}
+	h[urfBitsPerPixel] = byte(p.ColorSpace.bitsPerPixel())
+	switch {
+	case !p.Duplex:
+		h[urfDuplex] = urfSimplex
+	case p.Tumble:
+		h[urfDuplex] = urfShortEdge
+	default:
+		h[urfDuplex] = urfLongEdge
+	}
+	h[urfQuality] = byte(p.Quality)
+	h[urfMediaPosition] = byte(p.MediaPosition)
+	binary.BigEndian.PutUint32(h[urfWidth:], uint32(p.Width))
+	binary.BigEndian.PutUint32(h[urfHeight:], uint32(p.Height))
+	binary.BigEndian.PutUint32(h[urfResolution:], uint32(p.Resolution))
+	uw.w.Write(h[:])
+	encodeLines(uw.w, p)
+	return uw.w.Flush()
+}
+
+// URFReader reads pages in Apple Raster format.
+type URFReader struct {
+	r       *bufio.Reader
+	pages   int // pages left to read
+	started bool
+}
+
+// NewURFReader returns new URFReader that reads from r.
+func NewURFReader(r io.Reader) *URFReader {
+	return &URFReader{r: bufio.NewReader(r)}
+}
+
+// ReadPage reads next page. It returns io.EOF, when all
+// pages are read.
+func (ur *URFReader) ReadPage() (*Page, error) {
+	if !ur.started {
+		var fh [12]byte
+		if _, err := io.ReadFull(ur.r, fh[:]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if string(fh[:8]) != urfMagic {
+			return nil, ErrFormat
+		}
+		ur.pages = int(binary.BigEndian.Uint32(fh[8:]))
+		ur.started = true
+	}
+	if ur.pages == 0 {
+		return nil, io.EOF
+	}
+	var h [urfHeaderSize]byte
+	if _, err := io.ReadFull(ur.r, h[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	var cs ColorSpace
+	switch {
+	case (h[urfColorSpace] == urfSGray || h[urfColorSpace] == urfW) && h[urfBitsPerPixel] == 8:
+		cs = Gray
+	case (h[urfColorSpace] == urfSRGB || h[urfColorSpace] == urfRGB) && h[urfBitsPerPixel] == 24:
+		cs = RGB
+	default:
+		return nil, ErrColorSpace
+	}
+	p := &Page{
+		ColorSpace:    cs,
+		Width:         int(binary.BigEndian.Uint32(h[urfWidth:])),
+		Height:        int(binary.BigEndian.Uint32(h[urfHeight:])),
+		Resolution:    int(binary.BigEndian.Uint32(h[urfResolution:])),
+		MediaPosition: int(h[urfMediaPosition]),
+		Duplex:        h[urfDuplex] == urfShortEdge || h[urfDuplex] == urfLongEdge,
+		Tumble:        h[urfDuplex] == urfShortEdge,
+		Quality:       Quality(h[urfQuality]),
+	}
+	p.Stride = (p.Width*cs.bitsPerPixel() + 7) / 8
+	if err := p.checkSize(); err != nil {
+		return nil, err
+	}
+	p.Pix = make([]byte, p.Stride*p.Height)
+	if err := decodeLines(ur.r, p, 0xff); err != nil {
+		return nil, err
+	}
+	ur.pages--
+	return p, nil
+}
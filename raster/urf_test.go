@@ -0,0 +1,105 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raster
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestURF(t *testing.T) {
+	rand.Seed(4)
+	p1 := randomPage(RGB, 70, 30)
+	p1.Duplex = true
+	p1.Quality = QualityDraft
+	p2 := randomPage(Gray, 31, 12)
+	p2.Resolution = 600
+	p2.Duplex = true
+	p2.Tumble = true
+	p2.MediaPosition = 3
+	p3 := randomPage(Gray, 5, 1)
+	pages := []*Page{p1, p2, p3}
+
+	var b bytes.Buffer
+	w := NewURFWriter(&b, len(pages))
+	for _, p := range pages {
+		if err := w.WritePage(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := b.Bytes()
+	want := "UNIRAST\x00\x00\x00\x00\x03" +
+		"\x18\x01\x03\x03\x00\x00\x00\x00\x00\x00\x00\x00" +
+		"\x00\x00\x00\x46\x00\x00\x00\x1e\x00\x00\x01\x2c" +
+		"\x00\x00\x00\x00\x00\x00\x00\x00"
+	if got := string(data[:len(want)]); got != want {
+		t.Errorf("stream starts with\n%q\nwant\n%q", got, want)
+	}
+	var b2 bytes.Buffer
+	if err := NewURFWriter(&b2, 1).WritePage(p2); err != nil {
+		t.Fatal(err)
+	}
+	// Media position is in byte 5 of page header.
+	want = "\x08\x00\x02\x00\x00\x03\x00\x00\x00\x00\x00\x00" +
+		"\x00\x00\x00\x1f\x00\x00\x00\x0c\x00\x00\x02\x58" +
+		"\x00\x00\x00\x00\x00\x00\x00\x00"
+	if got := string(b2.Bytes()[12 : 12+len(want)]); got != want {
+		t.Errorf("page 2 header is\n%q\nwant\n%q", got, want)
+	}
+
+	r := NewURFReader(bytes.NewReader(data))
+	for i, want := range pages {
+		p, err := r.ReadPage()
+		if err != nil {
+			t.Fatalf("reading page %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(p, want) {
+			t.Errorf("page %d does not round trip:\n%+v\nwant\n%+v", i, p, want)
+		}
+	}
+	if _, err := r.ReadPage(); err != io.EOF {
+		t.Errorf("ReadPage after the last page returned %v, want io.EOF", err)
+	}
+
+	for i, data := range [][]byte{[]byte("UNIRAS"), data[:40], data[:len(data)-1]} {
+		r := NewURFReader(bytes.NewReader(data))
+		var err error
+		for err == nil {
+			_, err = r.ReadPage()
+		}
+		if err == io.EOF {
+			t.Errorf("reading bad data %d returned io.EOF", i)
+		}
+	}
+	if err := NewURFWriter(&b, 1).WritePage(NewPage(Black, 8, 8, 300)); err != ErrColorSpace {
+		t.Errorf("writing black page returned %v, want %v", err, ErrColorSpace)
+	}
+}
+
+func TestPWGToURF(t *testing.T) {
+	p := randomPage(Gray, 40, 20)
+	var pwg bytes.Buffer
+	if err := NewPWGWriter(&pwg).WritePage(p); err != nil {
+		t.Fatal(err)
+	}
+	q, err := NewPWGReader(&pwg).ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var urf bytes.Buffer
+	if err := NewURFWriter(&urf, 1).WritePage(q); err != nil {
+		t.Fatal(err)
+	}
+	q, err = NewURFReader(&urf).ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(q.Pix, p.Pix) {
+		t.Error("page pixels changed after conversion from PWG Raster to Apple Raster")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"os"
+	"time"
+)
+
+//sys	ReadPrinter(h syscall.Handle, buf *byte, bufN uint32, read *uint32) (err error) = winspool.ReadPrinter
+
+// readPrinterFunc calls ReadPrinter. Tests replace it.
+var readPrinterFunc = ReadPrinter
+
+// readPollInterval is the longest time Read waits before asking
+// printer for data again, when printer has no data to send.
+var readPollInterval = 100 * time.Millisecond
+
+// readCloseTimeout is how long Close waits for pending ReadPrinter
+// call to return, before closing printer handle under it.
+var readCloseTimeout = time.Second
+
+// readResult is the outcome of ReadPrinter call.
+type readResult struct {
+	b   []byte
+	err error
+}
+
+// pollPrinter calls ReadPrinter until printer sends some data or
+// ReadPrinter fails, and sends the result to c. Between calls that
+// return no data it waits, increasing the wait up to readPollInterval.
+// It stops early, if cancel is closed.
+func (p *Printer) pollPrinter(b []byte, c chan<- readResult, cancel <-chan struct{}) {
+	wait := time.Millisecond
+	for {
+		var read uint32
+		err := readPrinterFunc(p.h, &b[0], uint32(len(b)), &read)
+		if err != nil || read > 0 {
+			if err != nil {
+				read = 0
+			}
+			c <- readResult{b: b[:read], err: err}
+			return
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-cancel:
+			t.Stop()
+			c <- readResult{err: os.ErrClosed}
+			return
+		}
+		if wait *= 2; wait > readPollInterval {
+			wait = readPollInterval
+		}
+	}
+}
+
+// SetReadDeadline sets deadline for future Read calls. Zero t
+// means Read does not time out.
+func (p *Printer) SetReadDeadline(t time.Time) error {
+	p.readDeadline = t
+	return nil
+}
+
+// Read reads data sent back by printer p, like PJL or ESC/POS
+// status replies. Printer port and driver must support
+// bidirectional communication. Read waits until printer sends
+// some data, asking printer for it repeatedly. If read deadline
+// (see SetReadDeadline) passes first, Read returns
+// os.ErrDeadlineExceeded, and the data will be returned by the
+// next Read call instead.
+func (p *Printer) Read(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if len(p.readBuf) > 0 {
+		n := copy(b, p.readBuf)
+		p.readBuf = p.readBuf[n:]
+		return n, nil
+	}
+	var timeout <-chan time.Time
+	if !p.readDeadline.IsZero() {
+		d := time.Until(p.readDeadline)
+		if d <= 0 {
+			return 0, os.ErrDeadlineExceeded
+		}
+		t := time.NewTimer(d)
+		defer t.Stop()
+		timeout = t.C
+	}
+	if p.readPending == nil {
+		c := make(chan readResult, 1)
+		p.readCancel = make(chan struct{})
+		go p.pollPrinter(make([]byte, len(b)), c, p.readCancel)
+		p.readPending = c
+	}
+	select {
+	case r := <-p.readPending:
+		p.readPending = nil
+		// r.b is empty, if r.err is set.
+		n := copy(b, r.b)
+		p.readBuf = r.b[n:]
+		return n, r.err
+	case <-timeout:
+		return 0, os.ErrDeadlineExceeded
+	}
+}
+
+// cancelRead stops pending Read call. It waits up to readCloseTimeout
+// for ReadPrinter call in progress to return.
+func (p *Printer) cancelRead() {
+	if p.readPending == nil {
+		return
+	}
+	close(p.readCancel)
+	t := time.NewTimer(readCloseTimeout)
+	defer t.Stop()
+	select {
+	case <-p.readPending:
+	case <-t.C:
+		// ReadPrinter is stuck. It will fail, when the handle
+		// is closed.
+	}
+	p.readPending = nil
+	p.readCancel = nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestRead(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	err = p.SetReadDeadline(time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("SetReadDeadline failed: %v", err)
+	}
+	buf := make([]byte, 100)
+	_, err = p.Read(buf)
+	if err != os.ErrDeadlineExceeded {
+		t.Fatalf("Read after deadline returned %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+
+	err = p.SetReadDeadline(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("SetReadDeadline failed: %v", err)
+	}
+	n, err := p.Read(buf)
+	// Most printers do not support reading, so just log the result.
+	t.Logf("Read returned %q, %v", buf[:n], err)
+}
+
+// fakeReadPrinter replaces ReadPrinter with f until returned
+// function is called.
+func fakeReadPrinter(f func(b []byte) (int, error)) func() {
+	readPrinterFunc = func(h syscall.Handle, buf *byte, bufN uint32, read *uint32) error {
+		n, err := f((*[1 << 20]byte)(unsafe.Pointer(buf))[:bufN:bufN])
+		*read = uint32(n)
+		return err
+	}
+	return func() { readPrinterFunc = ReadPrinter }
+}
+
+func TestReadPolls(t *testing.T) {
+	calls := 0
+	defer fakeReadPrinter(func(b []byte) (int, error) {
+		calls++
+		if calls < 5 {
+			return 0, nil
+		}
+		return copy(b, "@PJL"), nil
+	})()
+	p := &Printer{}
+	buf := make([]byte, 10)
+	n, err := p.Read(buf)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if got := string(buf[:n]); got != "@PJL" {
+		t.Fatalf("Read returned %q, want %q", got, "@PJL")
+	}
+	if calls != 5 {
+		t.Errorf("ReadPrinter called %d times, want 5", calls)
+	}
+}
+
+func TestReadDeadline(t *testing.T) {
+	defer fakeReadPrinter(func(b []byte) (int, error) {
+		return 0, nil
+	})()
+	p := &Printer{}
+	p.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	n, err := p.Read(make([]byte, 10))
+	if n != 0 || err != os.ErrDeadlineExceeded {
+		t.Fatalf("Read returned %d, %v, want 0, %v", n, err, os.ErrDeadlineExceeded)
+	}
+	p.cancelRead()
+}
+
+func TestCloseWithPendingRead(t *testing.T) {
+	stuck := make(chan struct{})
+	defer close(stuck)
+	defer fakeReadPrinter(func(b []byte) (int, error) {
+		<-stuck
+		return 0, syscall.EINVAL
+	})()
+	defer func(d time.Duration) { readCloseTimeout = d }(readCloseTimeout)
+	readCloseTimeout = 100 * time.Millisecond
+
+	p := &Printer{}
+	p.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	if _, err := p.Read(make([]byte, 10)); err != os.ErrDeadlineExceeded {
+		t.Fatalf("Read returned %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	done := make(chan struct{})
+	go func() {
+		p.Close() // fails, because p has no handle
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked by pending read")
+	}
+	if p.readPending != nil {
+		t.Error("Close left read pending")
+	}
+}
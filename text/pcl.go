@@ -0,0 +1,144 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// SymbolSet selects PCL symbol set used by WritePCL.
+type SymbolSet int
+
+const (
+	// Latin1 is ISO 8859-1 symbol set. Characters that
+	// do not fit are replaced with '?'.
+	Latin1 SymbolSet = iota
+	// UTF8 is Unicode UTF-8 symbol set, that is supported
+	// by some newer printers.
+	UTF8
+)
+
+// pclPageSizes maps PWG media names to PCL page size codes.
+var pclPageSizes = map[string]int{
+	"na_executive_7.25x10.5in": 1,
+	"na_letter_8.5x11in":       2,
+	"na_legal_8.5x14in":        3,
+	"na_ledger_11x17in":        6,
+	"iso_a6_105x148mm":         24,
+	"iso_a5_148x210mm":         25,
+	"iso_a4_210x297mm":         26,
+	"iso_a3_297x420mm":         27,
+	"jis_b5_182x257mm":         45,
+	"jis_b4_257x364mm":         46,
+	"jpn_hagaki_100x148mm":     71,
+	"jpn_oufuku_148x200mm":     72,
+	"na_monarch_3.875x7.5in":   80,
+	"na_number-10_4.125x9.5in": 81,
+	"iso_dl_110x220mm":         90,
+	"iso_c5_162x229mm":         91,
+	"iso_b5_176x250mm":         100,
+}
+
+// pjlString returns s that can be used in PJL quoted string.
+func pjlString(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '"' || r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, s)
+}
+
+// pclText returns s encoded in symbol set ss.
+func pclText(s string, ss SymbolSet) string {
+	if ss == UTF8 {
+		return s
+	}
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff {
+			r = '?'
+		}
+		b = append(b, byte(r))
+	}
+	return string(b)
+}
+
+const uel = "\x1b%-12345X"
+
+// WritePCL reads UTF-8 text from r and writes it to w as PCL 5
+// job formatted according to opts. opts can be nil. Page margins
+// are measured from PCL logical page edges, and not from physical
+// paper edges.
+func WritePCL(w io.Writer, r io.Reader, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	l := opts.layout()
+	pages, err := opts.paginate(r, l)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	title := pjlString(opts.Title)
+
+	bw.WriteString(uel)
+	fmt.Fprintf(bw, "@PJL JOB NAME=\"%s\"\r\n", title)
+	fmt.Fprintf(bw, "@PJL ENTER LANGUAGE=PCL\r\n")
+	bw.WriteString("\x1bE")
+	if code, ok := pclPageSizes[l.media.Name]; ok {
+		fmt.Fprintf(bw, "\x1b&l%dA", code)
+	}
+	orientation := 0
+	if opts.Landscape {
+		orientation = 1
+	}
+	fmt.Fprintf(bw, "\x1b&l%dO", orientation)
+	// Disable perforation skip, so text length controls page breaks.
+	bw.WriteString("\x1b&l0L")
+	// Vertical motion index is in 1/48 inch units and
+	// horizontal motion index in 1/120 inch units.
+	fmt.Fprintf(bw, "\x1b&l%sC", num(l.lineHeight*48/72))
+	fmt.Fprintf(bw, "\x1b&l%dE", int(math.Round(l.margins.Top/l.lineHeight)))
+	fmt.Fprintf(bw, "\x1b&l%dF", l.rows)
+	switch opts.SymbolSet {
+	case UTF8:
+		bw.WriteString("\x1b(18N")
+	default:
+		bw.WriteString("\x1b(0N")
+	}
+	// Fixed pitch Courier of the requested size.
+	fmt.Fprintf(bw, "\x1b(s0p%sh%sv0s0b4099T", num(72/l.charWidth), num(l.fontSize))
+	fmt.Fprintf(bw, "\x1b&k%sH", num(l.charWidth*120/72))
+	fmt.Fprintf(bw, "\x1b&a%dL", int(math.Round(l.margins.Left/l.charWidth)))
+
+	for i, lines := range pages {
+		n := i + 1
+		var out []string
+		if opts.Header != nil {
+			// Header and footer are printed as text, so they
+			// cannot contain PCL commands.
+			out = append(out, opts.fitLine(opts.Header(n, len(pages)), l), "")
+		}
+		out = append(out, lines...)
+		if opts.Footer != nil {
+			for len(out) < l.rows-1 {
+				out = append(out, "")
+			}
+			out = append(out, opts.fitLine(opts.Footer(n, len(pages)), l))
+		}
+		bw.WriteString(pclText(strings.Join(out, "\r\n"), opts.SymbolSet))
+		bw.WriteString("\f")
+	}
+	bw.WriteString("\x1bE")
+	bw.WriteString(uel)
+	fmt.Fprintf(bw, "@PJL EOJ NAME=\"%s\"\r\n", title)
+	bw.WriteString(uel)
+	return bw.Flush()
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+func TestWritePCL(t *testing.T) {
+	tests := []struct {
+		text string
+		opts *Options
+		want string
+	}{
+		{
+			text: "Hello\tworld\nGrüße ☺\n\fpage two\n",
+			opts: &Options{Title: "test \"quoted\"", Header: PageNumbers},
+			want: "\x1b%-12345X@PJL JOB NAME=\"test ?quoted?\"\r\n" +
+				"@PJL ENTER LANGUAGE=PCL\r\n" +
+				"\x1bE\x1b&l2A\x1b&l0O\x1b&l0L\x1b&l8C\x1b&l3E\x1b&l60F\x1b(0N" +
+				"\x1b(s0p12h10v0s0b4099T\x1b&k10H\x1b&a6L" +
+				"Page 1 of 2\r\n\r\nHello   world\r\nGr\xfc\xdfe ?\f" +
+				"Page 2 of 2\r\n\r\npage two\f" +
+				"\x1bE\x1b%-12345X@PJL EOJ NAME=\"test ?quoted?\"\r\n\x1b%-12345X",
+		},
+		{
+			text: "Grüße\nline two",
+			opts: &Options{Media: media.A4, Landscape: true, FontSize: 12, LinesPerInch: 8, SymbolSet: UTF8, Footer: PageNumbers},
+			want: "\x1b%-12345X@PJL JOB NAME=\"\"\r\n" +
+				"@PJL ENTER LANGUAGE=PCL\r\n" +
+				"\x1bE\x1b&l26A\x1b&l1O\x1b&l0L\x1b&l6C\x1b&l4E\x1b&l58F\x1b(18N" +
+				"\x1b(s0p10h12v0s0b4099T\x1b&k12H\x1b&a5L" +
+				"Grüße\r\nline two" + strings.Repeat("\r\n", 56) + "Page 1 of 1\f" +
+				"\x1bE\x1b%-12345X@PJL EOJ NAME=\"\"\r\n\x1b%-12345X",
+		},
+		{
+			// Header and footer cannot contain PCL commands,
+			// and they are cut to the line length.
+			text: "body",
+			opts: &Options{
+				Header: func(page, pages int) string { return "file\x1b&l5X\t" + strings.Repeat("x", 100) },
+				Footer: func(page, pages int) string { return "\x1bE" },
+			},
+			want: "\x1b%-12345X@PJL JOB NAME=\"\"\r\n" +
+				"@PJL ENTER LANGUAGE=PCL\r\n" +
+				"\x1bE\x1b&l2A\x1b&l0O\x1b&l0L\x1b&l8C\x1b&l3E\x1b&l60F\x1b(0N" +
+				"\x1b(s0p12h10v0s0b4099T\x1b&k10H\x1b&a6L" +
+				"file&l5X" + strings.Repeat(" ", 8) + strings.Repeat("x", 74) + "\r\n\r\nbody" + strings.Repeat("\r\n", 57) + "E\f" +
+				"\x1bE\x1b%-12345X@PJL EOJ NAME=\"\"\r\n\x1b%-12345X",
+		},
+	}
+	for i, test := range tests {
+		var b bytes.Buffer
+		err := WritePCL(&b, strings.NewReader(test.text), test.opts)
+		if err != nil {
+			t.Fatalf("test %d: WritePCL failed: %v", i, err)
+		}
+		if b.String() != test.want {
+			t.Errorf("test %d: unexpected PCL output:\n%q\nwant\n%q", i, b.String(), test.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// psString returns s as PostScript string literal. s is converted
+// to ISO Latin-1, and characters that do not fit are replaced with '?'.
+func psString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+		case r < 0x7f:
+			b.WriteRune(r)
+		case r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// psName returns s with characters that are not allowed in
+// DSC comment values replaced.
+func psName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, s)
+}
+
+const psProlog = `%%BeginProlog
+/Courier-Latin1 /Courier findfont dup length dict begin
+  { 1 index /FID ne { def } { pop pop } ifelse } forall
+  /Encoding ISOLatin1Encoding def
+  currentdict
+end definefont pop
+/L { moveto show } bind def
+%%EndProlog
+`
+
+// WritePostScript reads UTF-8 text from r and writes it to w as
+// DSC-conforming PostScript document formatted according to opts.
+// opts can be nil.
+func WritePostScript(w io.Writer, r io.Reader, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	l := opts.layout()
+	pages, err := opts.paginate(r, l)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	width, height := num(l.width), num(l.height)
+	orientation := "Portrait"
+	if opts.Landscape {
+		orientation = "Landscape"
+	}
+	mediaName := psName(l.media.Name)
+	fmt.Fprintf(bw, "%%!PS-Adobe-3.0\n")
+	if opts.Title != "" {
+		fmt.Fprintf(bw, "%%%%Title: %s\n", psName(opts.Title))
+	}
+	fmt.Fprintf(bw, "%%%%Creator: github.com/alexbrainman/printer/text\n")
+	fmt.Fprintf(bw, "%%%%Pages: %d\n", len(pages))
+	fmt.Fprintf(bw, "%%%%PageOrder: Ascend\n")
+	fmt.Fprintf(bw, "%%%%BoundingBox: 0 0 %d %d\n", int(l.width+0.5), int(l.height+0.5))
+	fmt.Fprintf(bw, "%%%%Orientation: %s\n", orientation)
+	fmt.Fprintf(bw, "%%%%DocumentMedia: %s %s %s 0 () ()\n", mediaName, width, height)
+	fmt.Fprintf(bw, "%%%%DocumentNeededResources: font Courier\n")
+	fmt.Fprintf(bw, "%%%%EndComments\n")
+	bw.WriteString(psProlog)
+	fmt.Fprintf(bw, "%%%%BeginSetup\n")
+	fmt.Fprintf(bw, "%%%%BeginFeature: *PageSize %s\n", mediaName)
+	fmt.Fprintf(bw, "<< /PageSize [%s %s] >> setpagedevice\n", width, height)
+	fmt.Fprintf(bw, "%%%%EndFeature\n")
+	fmt.Fprintf(bw, "%%%%EndSetup\n")
+
+	left := num(l.margins.Left)
+	// y returns baseline of line number row (starting from 0).
+	y := func(row int) string {
+		return num(l.pageHeight - l.margins.Top - l.fontSize - float64(row)*l.lineHeight)
+	}
+	for i, lines := range pages {
+		n := i + 1
+		fmt.Fprintf(bw, "%%%%Page: %d %d\n", n, n)
+		fmt.Fprintf(bw, "%%%%BeginPageSetup\n")
+		fmt.Fprintf(bw, "save\n")
+		if opts.Landscape {
+			fmt.Fprintf(bw, "90 rotate 0 -%s translate\n", width)
+		}
+		fmt.Fprintf(bw, "/Courier-Latin1 findfont %s scalefont setfont\n", num(l.fontSize))
+		fmt.Fprintf(bw, "%%%%EndPageSetup\n")
+		row := 0
+		if opts.Header != nil {
+			fmt.Fprintf(bw, "%s %s %s L\n", psString(opts.fitLine(opts.Header(n, len(pages)), l)), left, y(0))
+			row = 2
+		}
+		for _, line := range lines {
+			if line != "" {
+				fmt.Fprintf(bw, "%s %s %s L\n", psString(line), left, y(row))
+			}
+			row++
+		}
+		if opts.Footer != nil {
+			fmt.Fprintf(bw, "%s %s %s L\n", psString(opts.fitLine(opts.Footer(n, len(pages)), l)), left, y(l.rows-1))
+		}
+		fmt.Fprintf(bw, "restore\n")
+		fmt.Fprintf(bw, "showpage\n")
+	}
+	fmt.Fprintf(bw, "%%%%Trailer\n")
+	fmt.Fprintf(bw, "%%%%EOF\n")
+	return bw.Flush()
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+const wantPostScript = `%!PS-Adobe-3.0
+%%Title: test
+%%Creator: github.com/alexbrainman/printer/text
+%%Pages: 2
+%%PageOrder: Ascend
+%%BoundingBox: 0 0 595 842
+%%Orientation: Landscape
+%%DocumentMedia: iso_a4_210x297mm 595.28 841.89 0 () ()
+%%DocumentNeededResources: font Courier
+%%EndComments
+%%BeginProlog
+/Courier-Latin1 /Courier findfont dup length dict begin
+  { 1 index /FID ne { def } { pop pop } ifelse } forall
+  /Encoding ISOLatin1Encoding def
+  currentdict
+end definefont pop
+/L { moveto show } bind def
+%%EndProlog
+%%BeginSetup
+%%BeginFeature: *PageSize iso_a4_210x297mm
+<< /PageSize [595.28 841.89] >> setpagedevice
+%%EndFeature
+%%EndSetup
+%%Page: 1 1
+%%BeginPageSetup
+save
+90 rotate 0 -595.28 translate
+/Courier-Latin1 findfont 10 scalefont setfont
+%%EndPageSetup
+(Hello \(world\)) 36 549.28 L
+(Gr\374\337e ?) 36 537.28 L
+(Page 1 of 2) 36 45.28 L
+restore
+showpage
+%%Page: 2 2
+%%BeginPageSetup
+save
+90 rotate 0 -595.28 translate
+/Courier-Latin1 findfont 10 scalefont setfont
+%%EndPageSetup
+(page two) 36 549.28 L
+(Page 2 of 2) 36 45.28 L
+restore
+showpage
+%%Trailer
+%%EOF
+`
+
+func TestWritePostScript(t *testing.T) {
+	var b bytes.Buffer
+	err := WritePostScript(&b, strings.NewReader("Hello (world)\nGrüße ☺\n\fpage two\n"), &Options{
+		Title:     "test",
+		Media:     media.A4,
+		Landscape: true,
+		Footer:    PageNumbers,
+	})
+	if err != nil {
+		t.Fatalf("WritePostScript failed: %v", err)
+	}
+	if b.String() != wantPostScript {
+		t.Fatalf("unexpected PostScript output:\n%s", b.String())
+	}
+}
+
+func TestWritePostScriptPages(t *testing.T) {
+	var b bytes.Buffer
+	err := WritePostScript(&b, strings.NewReader(strings.Repeat("line\n", 150)), nil)
+	if err != nil {
+		t.Fatalf("WritePostScript failed: %v", err)
+	}
+	out := b.String()
+	if !strings.Contains(out, "%%Pages: 3\n") {
+		t.Errorf("document does not have 3 pages")
+	}
+	if n := strings.Count(out, "\n%%Page: "); n != 3 {
+		t.Errorf("document has %d %%%%Page comments, want 3", n)
+	}
+	if n := strings.Count(out, "showpage\n"); n != 3 {
+		t.Errorf("document has %d showpage operators, want 3", n)
+	}
+}
+
+func TestWritePostScriptLongHeader(t *testing.T) {
+	var b bytes.Buffer
+	err := WritePostScript(&b, strings.NewReader("body\n"), &Options{
+		Header: func(page, pages int) string { return "a\x1b\tb" + strings.Repeat("x", 200) },
+		Footer: func(page, pages int) string { return "\tend" },
+	})
+	if err != nil {
+		t.Fatalf("WritePostScript failed: %v", err)
+	}
+	out := b.String()
+	// Letter page with default margins and font fits 90 characters.
+	header := "(a       b" + strings.Repeat("x", 81) + ") 36 746 L\n"
+	if !strings.Contains(out, header) {
+		t.Errorf("header is not expanded and cut to the line length:\n%s", out)
+	}
+	if !strings.Contains(out, "(        end) 36 ") {
+		t.Errorf("footer tab is not expanded:\n%s", out)
+	}
+}
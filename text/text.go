@@ -0,0 +1,216 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package text formats plain UTF-8 text documents in printer languages,
+// so they can be printed on printers that do not accept plain text.
+package text
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+// Margins describes page margins in points (1/72 inch).
+type Margins struct {
+	Top, Bottom, Left, Right float64
+}
+
+// Options describes text document layout. The text is printed
+// with fixed pitch Courier font.
+type Options struct {
+	Title        string
+	Media        media.Size // media.Letter, if zero
+	Landscape    bool
+	Margins      Margins   // 36 points (half an inch) on all sides, if zero
+	FontSize     float64   // in points; 10, if zero
+	LinesPerInch float64   // line spacing; 72 / (1.2 * FontSize), if zero
+	TabWidth     int       // distance between tab stops; 8, if zero
+	Wrap         bool      // wrap long lines instead of truncating them
+	SymbolSet    SymbolSet // PCL symbol set; used by WritePCL only
+
+	// Header and Footer return text printed at the top and the bottom
+	// of page number page (starting from 1) out of pages. They can be nil.
+	Header func(page, pages int) string
+	Footer func(page, pages int) string
+}
+
+// PageNumbers can be used as Options Header or Footer to print
+// page numbers, like "Page 1 of 3".
+func PageNumbers(page, pages int) string {
+	return "Page " + strconv.Itoa(page) + " of " + strconv.Itoa(pages)
+}
+
+// layout describes text placement on a page. All distances are
+// in points.
+type layout struct {
+	media         media.Size
+	width, height float64 // page size, as if it is printed in portrait
+	pageWidth     float64 // page width, as text is printed
+	pageHeight    float64 // page height, as text is printed
+	margins       Margins
+	fontSize      float64
+	charWidth     float64
+	lineHeight    float64
+	cols          int // characters per line
+	rows          int // lines per page, including header and footer
+	bodyRows      int // text lines per page
+}
+
+// courierWidth is Courier font character width in font size units.
+const courierWidth = 0.6
+
+func (o *Options) layout() *layout {
+	var l layout
+	m := o.Media
+	if m.Width == 0 || m.Height == 0 {
+		m = media.Letter
+	}
+	if m.Name == "" {
+		m.Name = "custom"
+	}
+	l.media = m
+	l.width, l.height = m.Points()
+	l.pageWidth, l.pageHeight = l.width, l.height
+	if o.Landscape {
+		l.pageWidth, l.pageHeight = l.height, l.width
+	}
+	l.margins = o.Margins
+	if l.margins == (Margins{}) {
+		l.margins = Margins{Top: 36, Bottom: 36, Left: 36, Right: 36}
+	}
+	l.fontSize = o.FontSize
+	if l.fontSize <= 0 {
+		l.fontSize = 10
+	}
+	l.charWidth = courierWidth * l.fontSize
+	l.lineHeight = 1.2 * l.fontSize
+	if o.LinesPerInch > 0 {
+		l.lineHeight = 72 / o.LinesPerInch
+	}
+	l.cols = int((l.pageWidth - l.margins.Left - l.margins.Right) / l.charWidth)
+	if l.cols < 1 {
+		l.cols = 1
+	}
+	l.rows = int((l.pageHeight - l.margins.Top - l.margins.Bottom) / l.lineHeight)
+	l.bodyRows = l.rows
+	// Header and footer are separated from text by an empty line.
+	if o.Header != nil {
+		l.bodyRows -= 2
+	}
+	if o.Footer != nil {
+		l.bodyRows -= 2
+	}
+	if l.bodyRows < 1 {
+		l.bodyRows = 1
+	}
+	return &l
+}
+
+// expandTabs replaces tabs in s with spaces and removes
+// other control characters.
+func expandTabs(s string, tabWidth int) []rune {
+	var line []rune
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			n := tabWidth - len(line)%tabWidth
+			for i := 0; i < n; i++ {
+				line = append(line, ' ')
+			}
+		case unicode.IsControl(r):
+		default:
+			line = append(line, r)
+		}
+	}
+	return line
+}
+
+// fitLine returns s with tabs expanded and control characters removed,
+// cut to the line length of layout l.
+func (o *Options) fitLine(s string, l *layout) string {
+	line := expandTabs(s, o.tabWidth())
+	if len(line) > l.cols {
+		line = line[:l.cols]
+	}
+	return string(line)
+}
+
+func (o *Options) tabWidth() int {
+	if o.TabWidth <= 0 {
+		return 8
+	}
+	return o.TabWidth
+}
+
+// paginate reads text from r and splits it into pages of lines
+// that fit layout l. Form feed character starts new page.
+func (o *Options) paginate(r io.Reader, l *layout) ([][]string, error) {
+	tabWidth := o.tabWidth()
+	var pages [][]string
+	var page []string
+	addLine := func(line string) {
+		if len(page) == l.bodyRows {
+			pages = append(pages, page)
+			page = nil
+		}
+		page = append(page, line)
+	}
+	br := bufio.NewReader(r)
+	for {
+		s, err := br.ReadString('\n')
+		if len(s) > 0 || err == nil {
+			s = strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\r")
+			parts := strings.Split(s, "\f")
+			for i, part := range parts {
+				if i > 0 {
+					// Form feed starts new page, unless it is
+					// at the very start of the document.
+					if len(page) > 0 || len(pages) > 0 {
+						pages = append(pages, page)
+					}
+					page = nil
+				}
+				if part == "" && len(parts) > 1 {
+					continue
+				}
+				line := expandTabs(part, tabWidth)
+				if len(line) <= l.cols {
+					addLine(string(line))
+					continue
+				}
+				if !o.Wrap {
+					addLine(string(line[:l.cols]))
+					continue
+				}
+				for len(line) > l.cols {
+					addLine(string(line[:l.cols]))
+					line = line[l.cols:]
+				}
+				addLine(string(line))
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(page) > 0 || len(pages) == 0 {
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// num formats v with at most 2 decimal places.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
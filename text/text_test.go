@@ -0,0 +1,55 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+func TestLayout(t *testing.T) {
+	l := (&Options{}).layout()
+	// Letter with half inch margins and 10 point Courier.
+	if l.cols != 90 || l.rows != 60 || l.bodyRows != 60 {
+		t.Errorf("default layout is %d columns and %d (%d) rows, want 90 and 60 (60)", l.cols, l.rows, l.bodyRows)
+	}
+	l = (&Options{Media: media.A4, Landscape: true, FontSize: 12, LinesPerInch: 6, Header: PageNumbers}).layout()
+	if l.cols != 106 || l.rows != 43 || l.bodyRows != 41 {
+		t.Errorf("A4 landscape layout is %d columns and %d (%d) rows, want 106 and 43 (41)", l.cols, l.rows, l.bodyRows)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		text  string
+		wrap  bool
+		pages [][]string
+	}{
+		{"", false, [][]string{nil}},
+		{"one\ntwo\r\nthree", false, [][]string{{"one", "two"}, {"three"}}},
+		{"one\ntwo\nthree\nfour\nfive\n", false, [][]string{{"one", "two"}, {"three", "four"}, {"five"}}},
+		{"a\tb\n\tc", false, [][]string{{"a   b", "    c"}}},
+		{"abcdefghij\nxy", false, [][]string{{"abcdef", "xy"}}},
+		{"abcdefghijklmn\nxy", true, [][]string{{"abcdef", "ghijkl"}, {"mn", "xy"}}},
+		{"one\f\ntwo\n\fthree\f", false, [][]string{{"one"}, {"two"}, {"three"}}},
+		{"\fone\f\ftwo", false, [][]string{{"one"}, nil, {"two"}}},
+		{"bell\a\n", false, [][]string{{"bell"}}},
+		{"ŝŝŝŝŝŝŝ", false, [][]string{{"ŝŝŝŝŝŝ"}}},
+	}
+	l := &layout{cols: 6, rows: 2, bodyRows: 2}
+	for _, test := range tests {
+		o := &Options{TabWidth: 4, Wrap: test.wrap}
+		pages, err := o.paginate(strings.NewReader(test.text), l)
+		if err != nil {
+			t.Fatalf("paginate(%q) failed: %v", test.text, err)
+		}
+		if !reflect.DeepEqual(pages, test.pages) {
+			t.Errorf("paginate(%q) = %q, want %q", test.text, pages, test.pages)
+		}
+	}
+}
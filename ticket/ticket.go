@@ -0,0 +1,133 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ticket describes print job options independently of printer
+// language. Packages that produce print jobs, like pjl, ps and xps,
+// translate Ticket into commands of their language.
+//
+// Zero value of every option means printer default.
+package ticket
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+// Duplex selects printing on one or both sides of paper.
+type Duplex int
+
+const (
+	DuplexDefault Duplex = iota
+	OneSided
+	TwoSidedLongEdge  // pages are turned over long edge, like a book
+	TwoSidedShortEdge // pages are turned over short edge, like a notepad
+)
+
+// ColorMode selects color or monochrome printing.
+type ColorMode int
+
+const (
+	ColorDefault ColorMode = iota
+	Monochrome
+	Color
+)
+
+// Ticket is set of print job options.
+type Ticket struct {
+	JobName    string
+	UserName   string
+	Copies     int
+	Collate    bool // print copies of the whole document one after another
+	Duplex     Duplex
+	Media      media.Size // paper size; zero Size means printer default
+	Tray       string     // PWG media-source keyword, like "tray-1" or "manual"
+	Resolution int        // in dpi
+	Color      ColorMode
+	Staple     bool // staple top left corner
+}
+
+var ErrOption = errors.New("ticket: invalid option value")
+
+// Parse returns ticket with options set by IPP attributes opts, as
+// used by CUPS lp command, like "copies", "sides", "media",
+// "media-source", "print-color-mode", "printer-resolution",
+// "multiple-document-handling", "finishings", "job-name" and
+// "requesting-user-name". Other attributes are ignored.
+func Parse(opts map[string]string) (*Ticket, error) {
+	t := new(Ticket)
+	for k, v := range opts {
+		switch k {
+		case "job-name":
+			t.JobName = v
+		case "requesting-user-name":
+			t.UserName = v
+		case "copies":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, ErrOption
+			}
+			t.Copies = n
+		case "collate":
+			// CUPS lp command option. Standard IPP attribute
+			// wins, if both are set.
+			if _, ok := opts["multiple-document-handling"]; !ok {
+				t.Collate = v == "true"
+			}
+		case "multiple-document-handling":
+			t.Collate = v == "separate-documents-collated-copies"
+		case "sides":
+			switch v {
+			case "one-sided":
+				t.Duplex = OneSided
+			case "two-sided-long-edge":
+				t.Duplex = TwoSidedLongEdge
+			case "two-sided-short-edge":
+				t.Duplex = TwoSidedShortEdge
+			default:
+				return nil, ErrOption
+			}
+		case "media":
+			s, ok := media.Lookup(v)
+			if !ok {
+				return nil, ErrOption
+			}
+			t.Media = s
+		case "media-source":
+			t.Tray = v
+		case "print-color-mode":
+			switch v {
+			case "monochrome":
+				t.Color = Monochrome
+			case "color":
+				t.Color = Color
+			case "auto":
+			default:
+				return nil, ErrOption
+			}
+		case "printer-resolution":
+			// Resolution is like "600dpi" or "600x600dpi".
+			s := strings.TrimSuffix(v, "dpi")
+			if i := strings.IndexByte(s, 'x'); i >= 0 {
+				s = s[:i]
+			}
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 || !strings.HasSuffix(v, "dpi") {
+				return nil, ErrOption
+			}
+			t.Resolution = n
+		case "finishings":
+			// Finishings are enum values or keywords.
+			for _, f := range strings.Split(v, ",") {
+				switch f {
+				case "4", "20", "staple", "staple-top-left":
+					t.Staple = true
+				}
+			}
+		}
+	}
+	return t, nil
+}
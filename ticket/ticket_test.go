@@ -0,0 +1,74 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ticket
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+)
+
+func TestParse(t *testing.T) {
+	got, err := Parse(map[string]string{
+		"job-name":             "report",
+		"requesting-user-name": "alex",
+		"copies":               "3",
+		"collate":              "true",
+		"sides":                "two-sided-short-edge",
+		"media":                "a4",
+		"media-source":         "tray-2",
+		"print-color-mode":     "monochrome",
+		"printer-resolution":   "600x600dpi",
+		"finishings":           "3,20",
+		"fit-to-page":          "true",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Ticket{
+		JobName:    "report",
+		UserName:   "alex",
+		Copies:     3,
+		Collate:    true,
+		Duplex:     TwoSidedShortEdge,
+		Media:      media.A4,
+		Tray:       "tray-2",
+		Resolution: 600,
+		Color:      Monochrome,
+		Staple:     true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+	for i := 0; i < 10; i++ {
+		got, err := Parse(map[string]string{
+			"collate":                    "true",
+			"multiple-document-handling": "separate-documents-uncollated-copies",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Collate {
+			t.Fatal("multiple-document-handling must take precedence over collate")
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, opts := range []map[string]string{
+		{"copies": "0"},
+		{"copies": "many"},
+		{"sides": "both"},
+		{"media": "a4paper"},
+		{"print-color-mode": "sepia"},
+		{"printer-resolution": "600"},
+		{"printer-resolution": "xdpi"},
+	} {
+		if _, err := Parse(opts); err != ErrOption {
+			t.Errorf("%v: got %v, want %v", opts, err, ErrOption)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tspl builds TSPL labels for TSC and compatible label
+// printers. The result can be sent to the printer as raw data, for
+// example with printer StartRawDocument and Write.
+//
+// All positions are in printer dots.
+package tspl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+// Barcode types.
+const (
+	Code128 = "128"
+	Code39  = "39"
+	EAN13   = "EAN13"
+	UPCA    = "UPCA"
+)
+
+// HRI is barcode human readable text alignment.
+type HRI int
+
+const (
+	HRINone   HRI = 0
+	HRILeft   HRI = 1
+	HRICenter HRI = 2
+	HRIRight  HRI = 3
+)
+
+// Builder builds TSPL commands. Builder methods append commands
+// to the stream, and the result is returned by Bytes or written
+// by WriteTo. Every label must be started with Clear and
+// finished with Print.
+type Builder struct {
+	buf bytes.Buffer
+}
+
+// New returns new empty Builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Bytes returns commands built so far.
+func (b *Builder) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// WriteTo writes commands built so far to w.
+func (b *Builder) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.buf.Bytes())
+	return int64(n), err
+}
+
+// cmd appends single command line.
+func (b *Builder) cmd(format string, args ...interface{}) {
+	fmt.Fprintf(&b.buf, format, args...)
+	b.buf.WriteString("\r\n")
+}
+
+// quote returns s as TSPL quoted string.
+func quote(s string) string {
+	s = strings.NewReplacer(`"`, `\["]`, "\n", " ", "\r", " ").Replace(s)
+	return `"` + s + `"`
+}
+
+// mm formats v millimeters.
+func mm(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64) + " mm"
+}
+
+// Size sets label width and length in millimeters (SIZE).
+func (b *Builder) Size(width, length float64) {
+	b.cmd("SIZE %s,%s", mm(width), mm(length))
+}
+
+// Gap sets distance between labels and gap offset in millimeters (GAP).
+func (b *Builder) Gap(gap, offset float64) {
+	b.cmd("GAP %s,%s", mm(gap), mm(offset))
+}
+
+// Direction sets printout direction: 0 or 1 (DIRECTION).
+func (b *Builder) Direction(d int) {
+	b.cmd("DIRECTION %d", d)
+}
+
+// Clear clears image buffer (CLS).
+func (b *Builder) Clear() {
+	b.cmd("CLS")
+}
+
+// Text prints text s with font, like "1" to "8", rotated rotation
+// degrees and magnified xmul times horizontally and ymul times
+// vertically (TEXT).
+func (b *Builder) Text(x, y int, font string, rotation, xmul, ymul int, s string) {
+	b.cmd("TEXT %d,%d,%s,%d,%d,%d,%s", x, y, quote(font), rotation, xmul, ymul, quote(s))
+}
+
+// Barcode prints barcode of type typ, like Code128, with narrow and
+// wide bars of the given width and height (BARCODE).
+func (b *Builder) Barcode(x, y int, typ string, height int, hri HRI, rotation, narrow, wide int, data string) {
+	b.cmd("BARCODE %d,%d,%s,%d,%d,%d,%d,%d,%s", x, y, quote(typ), height, hri, rotation, narrow, wide, quote(data))
+}
+
+// QRCode prints QR code with error correction level 'L', 'M', 'Q'
+// or 'H' and module size of cell dots (QRCODE).
+func (b *Builder) QRCode(x, y int, level byte, cell, rotation int, data string) {
+	b.cmd("QRCODE %d,%d,%c,%d,A,%d,%s", x, y, level, cell, rotation, quote(data))
+}
+
+// Bar draws filled black rectangle (BAR).
+func (b *Builder) Bar(x, y, width, height int) {
+	b.cmd("BAR %d,%d,%d,%d", x, y, width, height)
+}
+
+// Box draws rectangle border thickness dots thick (BOX).
+func (b *Builder) Box(x, y, width, height, thickness int) {
+	b.cmd("BOX %d,%d,%d,%d,%d", x, y, x+width, y+height, thickness)
+}
+
+// Bitmap prints bitmap bm at x, y (BITMAP).
+func (b *Builder) Bitmap(x, y int, bm *bitmap.Bitmap) {
+	fmt.Fprintf(&b.buf, "BITMAP %d,%d,%d,%d,0,", x, y, bm.Stride, bm.Height)
+	// TSPL prints cleared bits as black dots.
+	data := make([]byte, len(bm.Pix))
+	for i, c := range bm.Pix {
+		data[i] = ^c
+	}
+	b.buf.Write(data)
+	b.buf.WriteString("\r\n")
+}
+
+// Print prints the label copies times (PRINT).
+func (b *Builder) Print(copies int) {
+	b.cmd("PRINT 1,%d", copies)
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tspl
+
+import (
+	"testing"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+func TestBuilder(t *testing.T) {
+	bm := bitmap.New(10, 2)
+	bm.Set(9, 1, true)
+	b := New()
+	b.Size(101.6, 152.4)
+	b.Gap(3, 0)
+	b.Direction(1)
+	b.Clear()
+	b.Text(50, 60, "3", 90, 2, 2, `say "hi"`)
+	b.Barcode(50, 200, Code128, 100, HRICenter, 0, 2, 6, "ABC123")
+	b.QRCode(400, 200, 'M', 5, 0, "data")
+	b.Bar(0, 0, 812, 4)
+	b.Box(10, 10, 100, 50, 3)
+	b.Bitmap(5, 6, bm)
+	b.Print(2)
+	want := "SIZE 101.6 mm,152.4 mm\r\nGAP 3 mm,0 mm\r\nDIRECTION 1\r\nCLS\r\n" +
+		"TEXT 50,60,\"3\",90,2,2,\"say \\[\"]hi\\[\"]\"\r\n" +
+		"BARCODE 50,200,\"128\",100,2,0,2,6,\"ABC123\"\r\n" +
+		"QRCODE 400,200,M,5,A,0,\"data\"\r\n" +
+		"BAR 0,0,812,4\r\n" +
+		"BOX 10,10,110,60,3\r\n" +
+		"BITMAP 5,6,2,2,0,\xff\xff\xff\xbf\r\n" +
+		"PRINT 1,2\r\n"
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("unexpected TSPL:\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"context"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	PRINTER_CHANGE_ADD_PRINTER = 0x00000001
+	PRINTER_CHANGE_SET_PRINTER = 0x00000002
+	PRINTER_CHANGE_PRINTER     = 0x000000FF
+	PRINTER_CHANGE_ADD_JOB     = 0x00000100
+	PRINTER_CHANGE_SET_JOB     = 0x00000200
+	PRINTER_CHANGE_DELETE_JOB  = 0x00000400
+	PRINTER_CHANGE_WRITE_JOB   = 0x00000800
+	PRINTER_CHANGE_JOB         = 0x0000FF00
+)
+
+const (
+	PRINTER_STATUS_PAUSED            = 0x00000001
+	PRINTER_STATUS_ERROR             = 0x00000002
+	PRINTER_STATUS_PENDING_DELETION  = 0x00000004
+	PRINTER_STATUS_PAPER_JAM         = 0x00000008
+	PRINTER_STATUS_PAPER_OUT         = 0x00000010
+	PRINTER_STATUS_MANUAL_FEED       = 0x00000020
+	PRINTER_STATUS_PAPER_PROBLEM     = 0x00000040
+	PRINTER_STATUS_OFFLINE           = 0x00000080
+	PRINTER_STATUS_IO_ACTIVE         = 0x00000100
+	PRINTER_STATUS_BUSY              = 0x00000200
+	PRINTER_STATUS_PRINTING          = 0x00000400
+	PRINTER_STATUS_OUTPUT_BIN_FULL   = 0x00000800
+	PRINTER_STATUS_NOT_AVAILABLE     = 0x00001000
+	PRINTER_STATUS_WAITING           = 0x00002000
+	PRINTER_STATUS_PROCESSING        = 0x00004000
+	PRINTER_STATUS_INITIALIZING      = 0x00008000
+	PRINTER_STATUS_WARMING_UP        = 0x00010000
+	PRINTER_STATUS_TONER_LOW         = 0x00020000
+	PRINTER_STATUS_NO_TONER          = 0x00040000
+	PRINTER_STATUS_PAGE_PUNT         = 0x00080000
+	PRINTER_STATUS_USER_INTERVENTION = 0x00100000
+	PRINTER_STATUS_OUT_OF_MEMORY     = 0x00200000
+	PRINTER_STATUS_DOOR_OPEN         = 0x00400000
+	PRINTER_STATUS_SERVER_UNKNOWN    = 0x00800000
+	PRINTER_STATUS_POWER_SAVE        = 0x01000000
+)
+
+//sys	FindFirstPrinterChangeNotification(h syscall.Handle, filter uint32, options uint32, notifyOptions uintptr) (change syscall.Handle, err error) [failretval==syscall.InvalidHandle] = winspool.FindFirstPrinterChangeNotification
+//sys	FindNextPrinterChangeNotification(change syscall.Handle, cause *uint32, notifyOptions uintptr, notifyInfo uintptr) (err error) = winspool.FindNextPrinterChangeNotification
+//sys	FindClosePrinterChangeNotification(change syscall.Handle) (err error) = winspool.FindClosePrinterChangeNotification
+
+// EventType describes kind of change reported by Watch.
+type EventType int
+
+const (
+	JobAdded EventType = iota + 1
+	JobChanged
+	JobDeleted
+	PrinterChanged
+)
+
+func (t EventType) String() string {
+	switch t {
+	case JobAdded:
+		return "JobAdded"
+	case JobChanged:
+		return "JobChanged"
+	case JobDeleted:
+		return "JobDeleted"
+	case PrinterChanged:
+		return "PrinterChanged"
+	}
+	return "EventType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// WatchFilter selects changes reported by Watch.
+type WatchFilter uint32
+
+const (
+	WatchJobs WatchFilter = 1 << iota
+	WatchPrinter
+
+	WatchAll = WatchJobs | WatchPrinter
+)
+
+// Event describes printer or print job change.
+type Event struct {
+	Type    EventType
+	Job     JobInfo  // job that changed; last known job state for JobDeleted
+	Status  uint32   // printer status (PRINTER_STATUS_* flags) for PrinterChanged
+	Changed []string // names of changed JobInfo fields for JobChanged
+	Err     error    // set on the last event, if watching failed
+}
+
+// pollInterval is how often Watch checks printer, when printer
+// change notifications are not available.
+var pollInterval = 2 * time.Second
+
+// watchState is printer state Watch compares to find changes.
+type watchState struct {
+	status uint32
+	jobs   []JobInfo
+}
+
+func (p *Printer) watchState(filter WatchFilter) (*watchState, error) {
+	var s watchState
+	if filter&WatchPrinter != 0 {
+		pi, _, err := p.getPrinter2()
+		if err != nil {
+			return nil, err
+		}
+		s.status = pi.Status
+	}
+	if filter&WatchJobs != 0 {
+		jobs, err := p.Jobs()
+		if err != nil {
+			return nil, err
+		}
+		s.jobs = jobs
+	}
+	return &s, nil
+}
+
+// changedJobFields returns names of JobInfo fields that differ
+// between a and b.
+func changedJobFields(a, b *JobInfo) []string {
+	var changed []string
+	check := func(name string, differ bool) {
+		if differ {
+			changed = append(changed, name)
+		}
+	}
+	check("UserMachineName", a.UserMachineName != b.UserMachineName)
+	check("UserName", a.UserName != b.UserName)
+	check("DocumentName", a.DocumentName != b.DocumentName)
+	check("DataType", a.DataType != b.DataType)
+	check("Status", a.Status != b.Status)
+	check("StatusCode", a.StatusCode != b.StatusCode)
+	check("Priority", a.Priority != b.Priority)
+	check("Position", a.Position != b.Position)
+	check("TotalPages", a.TotalPages != b.TotalPages)
+	check("PagesPrinted", a.PagesPrinted != b.PagesPrinted)
+	check("Submitted", !a.Submitted.Equal(b.Submitted))
+	return changed
+}
+
+// diffStates returns events that turn old state into new.
+func diffStates(old, new *watchState) []Event {
+	var events []Event
+	if old.status != new.status {
+		events = append(events, Event{Type: PrinterChanged, Status: new.status})
+	}
+	newJobs := make(map[uint32]bool, len(new.jobs))
+	for _, j := range new.jobs {
+		newJobs[j.JobID] = true
+	}
+	oldJobs := make(map[uint32]*JobInfo, len(old.jobs))
+	for i := range old.jobs {
+		j := &old.jobs[i]
+		oldJobs[j.JobID] = j
+		if !newJobs[j.JobID] {
+			events = append(events, Event{Type: JobDeleted, Job: *j})
+		}
+	}
+	for _, j := range new.jobs {
+		o, ok := oldJobs[j.JobID]
+		if !ok {
+			events = append(events, Event{Type: JobAdded, Job: j})
+			continue
+		}
+		if changed := changedJobFields(o, &j); len(changed) > 0 {
+			events = append(events, Event{Type: JobChanged, Job: j, Changed: changed})
+		}
+	}
+	return events
+}
+
+// Watch reports changes of printer p and its jobs selected by filter.
+// It uses printer change notifications, if printer supports them, or
+// polls printer otherwise. Returned channel is closed when ctx is done
+// or when watching fails. Printer p must not be closed until then.
+// CUPS printers are watched with ipp.Client.Watch.
+func (p *Printer) Watch(ctx context.Context, filter WatchFilter) (<-chan Event, error) {
+	if filter == 0 {
+		filter = WatchAll
+	}
+	state, err := p.watchState(filter)
+	if err != nil {
+		return nil, err
+	}
+	var flags uint32
+	if filter&WatchPrinter != 0 {
+		flags |= PRINTER_CHANGE_PRINTER
+	}
+	if filter&WatchJobs != 0 {
+		flags |= PRINTER_CHANGE_JOB
+	}
+	change, err := FindFirstPrinterChangeNotification(p.h, flags, 0, 0)
+	if err != nil {
+		change = syscall.InvalidHandle
+	}
+	c := make(chan Event)
+	go func() {
+		defer close(c)
+		if change != syscall.InvalidHandle {
+			defer FindClosePrinterChangeNotification(change)
+		}
+		send := func(e Event) bool {
+			select {
+			case c <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for {
+			err := waitForChange(ctx, change)
+			if err == context.Canceled || err == context.DeadlineExceeded {
+				return
+			}
+			var next *watchState
+			if err == nil {
+				next, err = p.watchState(filter)
+			}
+			if err != nil {
+				send(Event{Err: err})
+				return
+			}
+			for _, e := range diffStates(state, next) {
+				if !send(e) {
+					return
+				}
+			}
+			state = next
+		}
+	}()
+	return c, nil
+}
+
+// waitForChange waits until change notification is signalled or,
+// if change is syscall.InvalidHandle, until it is time to poll again.
+// It returns ctx.Err() when ctx is done.
+func waitForChange(ctx context.Context, change syscall.Handle) error {
+	if change == syscall.InvalidHandle {
+		t := time.NewTimer(pollInterval)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Wake up periodically to check ctx.
+		e, err := syscall.WaitForSingleObject(change, 250)
+		if err != nil {
+			return err
+		}
+		if e == syscall.WAIT_TIMEOUT {
+			continue
+		}
+		var cause uint32
+		return FindNextPrinterChangeNotification(change, &cause, 0, 0)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package printer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffStates(t *testing.T) {
+	old := &watchState{
+		status: 0,
+		jobs: []JobInfo{
+			{JobID: 1, DocumentName: "one", Status: "Spooling", TotalPages: 1},
+			{JobID: 2, DocumentName: "two", Status: "Printing"},
+		},
+	}
+	new := &watchState{
+		status: PRINTER_STATUS_PAPER_OUT,
+		jobs: []JobInfo{
+			{JobID: 1, DocumentName: "one", Status: "Printing", TotalPages: 3, PagesPrinted: 1},
+			{JobID: 3, DocumentName: "three"},
+		},
+	}
+	want := []Event{
+		{Type: PrinterChanged, Status: PRINTER_STATUS_PAPER_OUT},
+		{Type: JobDeleted, Job: old.jobs[1]},
+		{Type: JobChanged, Job: new.jobs[0], Changed: []string{"Status", "TotalPages", "PagesPrinted"}},
+		{Type: JobAdded, Job: new.jobs[1]},
+	}
+	events := diffStates(old, new)
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("diffStates returned\n%+v\nwant\n%+v", events, want)
+	}
+	if events := diffStates(new, new); len(events) != 0 {
+		t.Fatalf("diffStates of the same state returned %+v", events)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	name, err := Default()
+	if err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	p, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	events, err := p.Watch(ctx, WatchAll)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	for e := range events {
+		if e.Err != nil {
+			t.Fatalf("Watch failed: %v", e.Err)
+		}
+		t.Logf("%v: %+v", e.Type, e)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xps
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/alexbrainman/printer/ticket"
+)
+
+// mediaSizes maps PWG media names to Print Schema PageMediaSize options.
+var mediaSizes = map[string]string{
+	"iso_a3_297x420mm":         "ISOA3",
+	"iso_a4_210x297mm":         "ISOA4",
+	"iso_a5_148x210mm":         "ISOA5",
+	"iso_a6_105x148mm":         "ISOA6",
+	"jis_b4_257x364mm":         "JISB4",
+	"jis_b5_182x257mm":         "JISB5",
+	"iso_c5_162x229mm":         "ISOC5Envelope",
+	"iso_dl_110x220mm":         "ISODLEnvelope",
+	"na_letter_8.5x11in":       "NorthAmericaLetter",
+	"na_legal_8.5x14in":        "NorthAmericaLegal",
+	"na_ledger_11x17in":        "NorthAmericaTabloid",
+	"na_executive_7.25x10.5in": "NorthAmericaExecutive",
+	"na_invoice_5.5x8.5in":     "NorthAmericaStatement",
+	"na_number-10_4.125x9.5in": "NorthAmericaNumber10Envelope",
+	"na_monarch_3.875x7.5in":   "NorthAmericaMonarchEnvelope",
+	"jpn_hagaki_100x148mm":     "JapanHagakiPostcard",
+}
+
+// inputBins maps PWG media-source keywords to Print Schema
+// JobInputBin options.
+var inputBins = map[string]string{
+	"auto":     "AutoSelect",
+	"manual":   "Manual",
+	"envelope": "EnvelopeFeed",
+	"tractor":  "Tractor",
+}
+
+// PrintTicket returns Print Schema job PrintTicket that sets options t.
+// Trays, that have no Print Schema keyword, like "tray-2", are ignored.
+// t can be nil.
+func PrintTicket(t *ticket.Ticket) []byte {
+	if t == nil {
+		t = &ticket.Ticket{}
+	}
+	var b bytes.Buffer
+	b.WriteString(xml.Header +
+		`<psf:PrintTicket xmlns:psf="http://schemas.microsoft.com/windows/2003/08/printing/printschemaframework"` +
+		` xmlns:psk="http://schemas.microsoft.com/windows/2003/08/printing/printschemakeywords"` +
+		` xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` +
+		` xmlns:xsd="http://www.w3.org/2001/XMLSchema" version="1">` + "\n")
+	feature := func(name, option string) {
+		fmt.Fprintf(&b, `<psf:Feature name="psk:%s"><psf:Option name="psk:%s"/></psf:Feature>`+"\n", name, option)
+	}
+	integer := func(name string, v int) string {
+		return fmt.Sprintf(`<psf:ScoredProperty name="psk:%s"><psf:Value xsi:type="xsd:integer">%d</psf:Value></psf:ScoredProperty>`, name, v)
+	}
+	if t.Copies > 0 {
+		fmt.Fprintf(&b, `<psf:ParameterInit name="psk:JobCopiesAllDocuments"><psf:Value xsi:type="xsd:integer">%d</psf:Value></psf:ParameterInit>`+"\n", t.Copies)
+	}
+	if t.Collate {
+		feature("DocumentCollate", "Collated")
+	}
+	switch t.Duplex {
+	case ticket.OneSided:
+		feature("JobDuplexAllDocumentsContiguously", "OneSided")
+	case ticket.TwoSidedLongEdge:
+		feature("JobDuplexAllDocumentsContiguously", "TwoSidedLongEdge")
+	case ticket.TwoSidedShortEdge:
+		feature("JobDuplexAllDocumentsContiguously", "TwoSidedShortEdge")
+	}
+	if t.Media.Width > 0 && t.Media.Height > 0 {
+		// Media size is in microns.
+		b.WriteString(`<psf:Feature name="psk:PageMediaSize"><psf:Option`)
+		if name, ok := mediaSizes[t.Media.Name]; ok {
+			fmt.Fprintf(&b, ` name="psk:%s"`, name)
+		}
+		b.WriteString(">" + integer("MediaSizeWidth", t.Media.Width*10) +
+			integer("MediaSizeHeight", t.Media.Height*10) + "</psf:Option></psf:Feature>\n")
+	}
+	if bin, ok := inputBins[t.Tray]; ok {
+		feature("JobInputBin", bin)
+	}
+	if t.Resolution > 0 {
+		b.WriteString(`<psf:Feature name="psk:PageResolution"><psf:Option>` +
+			integer("ResolutionX", t.Resolution) + integer("ResolutionY", t.Resolution) +
+			"</psf:Option></psf:Feature>\n")
+	}
+	switch t.Color {
+	case ticket.Monochrome:
+		feature("PageOutputColor", "Monochrome")
+	case ticket.Color:
+		feature("PageOutputColor", "Color")
+	}
+	if t.Staple {
+		feature("JobStapleAllDocuments", "StapleTopLeft")
+	}
+	b.WriteString("</psf:PrintTicket>\n")
+	return b.Bytes()
+}
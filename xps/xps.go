@@ -0,0 +1,468 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xps implements minimal OpenXPS (ECMA-388) document writer.
+// XPS documents can be printed by Windows v4 printer drivers with
+// XPS_PASS datatype, without conversion.
+//
+// Document consists of text in embedded TrueType or OpenType fonts,
+// lines, rectangles and images. All coordinates are in 1/96 inch with
+// origin in the top left corner of the page.
+package xps
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alexbrainman/printer/ticket"
+)
+
+var (
+	ErrClosed       = errors.New("xps: writer is closed")
+	ErrNoPage       = errors.New("xps: document has no pages")
+	ErrNoFont       = errors.New("xps: font is not set")
+	ErrBadFont      = errors.New("xps: invalid font data")
+	ErrBadJPEG      = errors.New("xps: invalid JPEG data")
+	ErrForeignImage = errors.New("xps: image belongs to another writer")
+	ErrForeignFont  = errors.New("xps: font belongs to another writer")
+)
+
+// OpenXPS namespace and relationship types.
+const (
+	nsXPS            = "http://schemas.openxps.org/oxps/v1.0"
+	nsRels           = "http://schemas.openxmlformats.org/package/2006/relationships"
+	relFixedRepr     = "http://schemas.openxps.org/oxps/v1.0/fixedrepresentation"
+	relResource      = "http://schemas.openxps.org/oxps/v1.0/required-resource"
+	relPrintTicket   = "http://schemas.openxps.org/oxps/v1.0/printticket"
+	relCoreProps     = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+	contentTypeProps = "application/vnd.openxmlformats-package.core-properties+xml"
+)
+
+// Part names.
+const (
+	seqPart         = "/FixedDocumentSequence.fdseq"
+	docPart         = "/Documents/1/FixedDocument.fdoc"
+	printTicketPart = "/Metadata/Job_PT.xml"
+	corePropsPart   = "/docProps/core.xml"
+)
+
+// Writer writes XPS document. Pages are added with NewPage, and the
+// document must be finished with Close.
+type Writer struct {
+	// Title is stored in the document core properties, if not
+	// empty. It must be set before Close.
+	Title string
+
+	z      *zip.Writer
+	pages  []*Page // written pages
+	page   *Page   // current page
+	fonts  int     // number of fonts
+	images int     // number of images
+	pt     bool    // document has PrintTicket
+	err    error
+	closed bool
+}
+
+// NewWriter returns new Writer that writes XPS document to w. Print
+// job options t are stored in the document PrintTicket. t can be nil.
+func NewWriter(w io.Writer, t *ticket.Ticket) *Writer {
+	xw := &Writer{z: zip.NewWriter(w)}
+	// Document sequence is written first, so the document can be
+	// recognized by its beginning.
+	xw.writePart(seqPart, true, []byte(xml.Header+
+		`<FixedDocumentSequence xmlns="`+nsXPS+`"><DocumentReference Source="`+docPart+`"/></FixedDocumentSequence>`))
+	if t != nil {
+		xw.pt = true
+		xw.writePart(printTicketPart, true, PrintTicket(t))
+		xw.writeRels(seqPart, []rel{{relPrintTicket, printTicketPart}})
+	}
+	return xw
+}
+
+// writePart writes package part name with content data.
+func (w *Writer) writePart(name string, compress bool, data []byte) {
+	if w.err != nil {
+		return
+	}
+	h := &zip.FileHeader{Name: strings.TrimPrefix(name, "/"), Method: zip.Store}
+	if compress {
+		h.Method = zip.Deflate
+	}
+	f, err := w.z.CreateHeader(h)
+	if err != nil {
+		w.err = err
+		return
+	}
+	_, w.err = f.Write(data)
+}
+
+type rel struct {
+	typ, target string
+}
+
+// writeRels writes relationships of part name.
+func (w *Writer) writeRels(name string, rels []rel) {
+	i := strings.LastIndexByte(name, '/')
+	var b bytes.Buffer
+	b.WriteString(xml.Header + `<Relationships xmlns="` + nsRels + `">`)
+	for n, r := range rels {
+		fmt.Fprintf(&b, `<Relationship Id="R%d" Type="%s" Target="%s"/>`, n+1, r.typ, escape(r.target))
+	}
+	b.WriteString(`</Relationships>`)
+	w.writePart(name[:i]+"/_rels"+name[i:]+".rels", true, b.Bytes())
+}
+
+// NewPage finishes current page, if any, and starts new page
+// of the given size.
+func (w *Writer) NewPage(width, height float64) (*Page, error) {
+	if w.closed {
+		return nil, ErrClosed
+	}
+	w.endPage()
+	if w.err != nil {
+		return nil, w.err
+	}
+	w.page = &Page{
+		w:           w,
+		width:       width,
+		height:      height,
+		lineWidth:   1,
+		strokeColor: "#000000",
+		fillColor:   "#000000",
+		used:        make(map[string]bool),
+	}
+	return w.page, nil
+}
+
+// endPage writes current page to the document.
+func (w *Writer) endPage() {
+	p := w.page
+	if p == nil {
+		return
+	}
+	w.page = nil
+	w.pages = append(w.pages, p)
+	name := fmt.Sprintf("/Documents/1/Pages/%d.fpage", len(w.pages))
+	var b bytes.Buffer
+	fmt.Fprintf(&b, xml.Header+`<FixedPage xmlns="%s" Width="%s" Height="%s" xml:lang="und">`,
+		nsXPS, num(p.width), num(p.height))
+	b.Write(p.content.Bytes())
+	b.WriteString(`</FixedPage>`)
+	w.writePart(name, true, b.Bytes())
+	if len(p.resources) > 0 {
+		rels := make([]rel, len(p.resources))
+		for i, r := range p.resources {
+			rels[i] = rel{relResource, r}
+		}
+		w.writeRels(name, rels)
+	}
+}
+
+// Close finishes the document. It does not close underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+	w.endPage()
+	if len(w.pages) == 0 {
+		return ErrNoPage
+	}
+	var b bytes.Buffer
+	b.WriteString(xml.Header + `<FixedDocument xmlns="` + nsXPS + `">`)
+	for i, p := range w.pages {
+		fmt.Fprintf(&b, `<PageContent Source="/Documents/1/Pages/%d.fpage" Width="%s" Height="%s"/>`,
+			i+1, num(p.width), num(p.height))
+	}
+	b.WriteString(`</FixedDocument>`)
+	w.writePart(docPart, true, b.Bytes())
+
+	rels := []rel{{relFixedRepr, seqPart}}
+	if w.Title != "" {
+		rels = append(rels, rel{relCoreProps, corePropsPart})
+		w.writePart(corePropsPart, true, []byte(xml.Header+
+			`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">`+
+			`<dc:title>`+escape(w.Title)+`</dc:title></cp:coreProperties>`))
+	}
+	w.writeRels("/", rels)
+
+	b.Reset()
+	b.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	for _, d := range [][2]string{
+		{"rels", "application/vnd.openxmlformats-package.relationships+xml"},
+		{"fdseq", "application/vnd.openxps-fixeddocumentsequence+xml"},
+		{"fdoc", "application/vnd.openxps-fixeddocument+xml"},
+		{"fpage", "application/vnd.openxps-fixedpage+xml"},
+		{"ttf", "application/vnd.ms-opentype"},
+		{"png", "image/png"},
+		{"jpg", "image/jpeg"},
+	} {
+		fmt.Fprintf(&b, `<Default Extension="%s" ContentType="%s"/>`, d[0], d[1])
+	}
+	if w.pt {
+		fmt.Fprintf(&b, `<Override PartName="%s" ContentType="application/vnd.ms-printing.printticket+xml"/>`, printTicketPart)
+	}
+	if w.Title != "" {
+		fmt.Fprintf(&b, `<Override PartName="%s" ContentType="%s"/>`, corePropsPart, contentTypeProps)
+	}
+	b.WriteString(`</Types>`)
+	w.writePart("/[Content_Types].xml", true, b.Bytes())
+	if w.err != nil {
+		return w.err
+	}
+	return w.z.Close()
+}
+
+// Font is TrueType or OpenType font embedded in the document.
+type Font struct {
+	w    *Writer
+	part string
+}
+
+// AddFont embeds TrueType or OpenType font data in the document.
+// Font collections are not supported.
+func (w *Writer) AddFont(data []byte) (*Font, error) {
+	if w.closed {
+		return nil, ErrClosed
+	}
+	if len(data) < 12 {
+		return nil, ErrBadFont
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "true", "OTTO":
+	default:
+		return nil, ErrBadFont
+	}
+	w.fonts++
+	f := &Font{w: w, part: fmt.Sprintf("/Documents/1/Resources/Fonts/%d.ttf", w.fonts)}
+	w.writePart(f.part, true, data)
+	return f, w.err
+}
+
+// Image is an image stored in the document. The same image can be
+// drawn many times on any page of the document.
+type Image struct {
+	w             *Writer
+	part          string
+	Width, Height int // in pixels
+	// Horizontal and vertical image resolution in dpi. Image
+	// brush uses it to map pixels to page units.
+	dpiX, dpiY float64
+}
+
+func (w *Writer) addImage(ext string, width, height int, dpiX, dpiY float64, data []byte) (*Image, error) {
+	w.images++
+	img := &Image{
+		w:      w,
+		part:   fmt.Sprintf("/Documents/1/Resources/Images/%d.%s", w.images, ext),
+		Width:  width,
+		Height: height,
+		dpiX:   dpiX,
+		dpiY:   dpiY,
+	}
+	// Images are compressed already.
+	w.writePart(img.part, false, data)
+	return img, w.err
+}
+
+// AddImage stores image m in the document in PNG format.
+func (w *Writer) AddImage(m image.Image) (*Image, error) {
+	if w.closed {
+		return nil, ErrClosed
+	}
+	var b bytes.Buffer
+	if err := png.Encode(&b, m); err != nil {
+		return nil, err
+	}
+	return w.addImage("png", m.Bounds().Dx(), m.Bounds().Dy(), 96, 96, b.Bytes())
+}
+
+// AddJPEG stores JPEG image data in the document as is.
+func (w *Writer) AddJPEG(data []byte) (*Image, error) {
+	if w.closed {
+		return nil, ErrClosed
+	}
+	c, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrBadJPEG
+	}
+	dpiX, dpiY := jfifDensity(data)
+	return w.addImage("jpg", c.Width, c.Height, dpiX, dpiY, data)
+}
+
+// jfifDensity returns image resolution stored in JFIF APP0 segment
+// of JPEG data. It returns 96 dpi, if resolution is not set.
+func jfifDensity(data []byte) (x, y float64) {
+	// APP0 segment follows start of image marker.
+	if len(data) < 18 || data[2] != 0xff || data[3] != 0xe0 || string(data[6:11]) != "JFIF\x00" {
+		return 96, 96
+	}
+	dx := float64(int(data[14])<<8 | int(data[15]))
+	dy := float64(int(data[16])<<8 | int(data[17]))
+	if dx == 0 || dy == 0 {
+		return 96, 96
+	}
+	switch data[13] {
+	case 1: // dots per inch
+		return dx, dy
+	case 2: // dots per cm
+		return dx * 2.54, dy * 2.54
+	}
+	return 96, 96
+}
+
+// Page is a document page returned by NewPage. Page content is
+// written to the document, when next page is started or when
+// the document is closed.
+type Page struct {
+	w             *Writer
+	width, height float64
+	content       bytes.Buffer
+	font          *Font
+	fontSize      float64
+	lineWidth     float64
+	strokeColor   string
+	fillColor     string
+	resources     []string        // fonts and images used by the page
+	used          map[string]bool // resources already listed
+}
+
+// use adds resource part to the page resources.
+func (p *Page) use(part string) {
+	if !p.used[part] {
+		p.used[part] = true
+		p.resources = append(p.resources, part)
+	}
+}
+
+// Size returns page width and height.
+func (p *Page) Size() (width, height float64) {
+	return p.width, p.height
+}
+
+// SetFont sets font used by Text.
+func (p *Page) SetFont(f *Font, size float64) error {
+	if f.w != p.w {
+		return ErrForeignFont
+	}
+	p.font = f
+	p.fontSize = size
+	return nil
+}
+
+// Text draws string s with baseline starting at x, y.
+// Text color is set with SetFillColor.
+func (p *Page) Text(x, y float64, s string) error {
+	if p.font == nil {
+		return ErrNoFont
+	}
+	// XML does not allow control characters.
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+	if s == "" {
+		return nil
+	}
+	if s[0] == '{' {
+		// String starting with { is escaped by {}.
+		s = "{}" + s
+	}
+	p.use(p.font.part)
+	fmt.Fprintf(&p.content, `<Glyphs FontUri="%s" FontRenderingEmSize="%s" OriginX="%s" OriginY="%s" UnicodeString="%s" Fill="%s"/>`,
+		p.font.part, num(p.fontSize), num(x), num(y), escape(s), p.fillColor)
+	return nil
+}
+
+// SetLineWidth sets width of lines drawn by Line and StrokeRect.
+func (p *Page) SetLineWidth(width float64) {
+	p.lineWidth = width
+}
+
+// colorAttr returns c as XPS color, like #RRGGBB or #AARRGGBB.
+func colorAttr(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf("#%02X%02X%02X", n.R, n.G, n.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", n.A, n.R, n.G, n.B)
+}
+
+// SetStrokeColor sets color of lines drawn by Line and StrokeRect.
+func (p *Page) SetStrokeColor(c color.Color) {
+	p.strokeColor = colorAttr(c)
+}
+
+// SetFillColor sets color used by FillRect and Text.
+func (p *Page) SetFillColor(c color.Color) {
+	p.fillColor = colorAttr(c)
+}
+
+// Line draws line from x1, y1 to x2, y2.
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, `<Path Data="M %s,%s L %s,%s" Stroke="%s" StrokeThickness="%s"/>`,
+		num(x1), num(y1), num(x2), num(y2), p.strokeColor, num(p.lineWidth))
+}
+
+// rect returns path data of rectangle with top left corner at x, y.
+func rect(x, y, width, height float64) string {
+	return fmt.Sprintf("M %s,%s L %s,%s %s,%s %s,%s Z",
+		num(x), num(y), num(x+width), num(y), num(x+width), num(y+height), num(x), num(y+height))
+}
+
+// StrokeRect draws outline of rectangle with top left corner at x, y.
+func (p *Page) StrokeRect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, `<Path Data="%s" Stroke="%s" StrokeThickness="%s"/>`,
+		rect(x, y, width, height), p.strokeColor, num(p.lineWidth))
+}
+
+// FillRect fills rectangle with top left corner at x, y.
+func (p *Page) FillRect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, `<Path Data="%s" Fill="%s"/>`, rect(x, y, width, height), p.fillColor)
+}
+
+// DrawImage draws image img scaled into rectangle with top
+// left corner at x, y.
+func (p *Page) DrawImage(img *Image, x, y, width, height float64) error {
+	if img.w != p.w {
+		return ErrForeignImage
+	}
+	p.use(img.part)
+	// Viewbox is image size in 1/96 inch.
+	fmt.Fprintf(&p.content, `<Path Data="%s"><Path.Fill><ImageBrush ImageSource="%s" Viewbox="0,0,%s,%s" ViewboxUnits="Absolute" Viewport="%s,%s,%s,%s" ViewportUnits="Absolute"/></Path.Fill></Path>`,
+		rect(x, y, width, height), img.part,
+		num(float64(img.Width)*96/img.dpiX), num(float64(img.Height)*96/img.dpiY),
+		num(x), num(y), num(width), num(height))
+	return nil
+}
+
+// escape returns s escaped for use in XML text and attributes.
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// num formats v with at most 2 decimal places.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
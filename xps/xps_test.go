@@ -0,0 +1,355 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xps
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/media"
+	"github.com/alexbrainman/printer/ticket"
+)
+
+// testFont is beginning of TrueType font file. Fonts are not parsed,
+// so it is enough for tests.
+var testFont = []byte("\x00\x01\x00\x00\x00\x0b\x00\x80\x00\x03\x00\x30")
+
+// readParts returns names and content of package parts in doc.
+func readParts(t *testing.T, doc []byte) ([]string, map[string]string) {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(doc), int64(len(doc)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	parts := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, f.Name)
+		parts["/"+f.Name] = string(b)
+	}
+	return names, parts
+}
+
+// checkXML verifies that s is well-formed XML document.
+func checkXML(t *testing.T, name, s string) {
+	t.Helper()
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+// checkPackage verifies that every part of the package has content
+// type, and every relationship points at existing part.
+func checkPackage(t *testing.T, parts map[string]string) {
+	t.Helper()
+	var types struct {
+		Default []struct {
+			Extension string `xml:",attr"`
+		}
+		Override []struct {
+			PartName string `xml:",attr"`
+		}
+	}
+	if err := xml.Unmarshal([]byte(parts["/[Content_Types].xml"]), &types); err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]bool)
+	for _, d := range types.Default {
+		known["."+d.Extension] = true
+	}
+	for _, o := range types.Override {
+		known[o.PartName] = true
+	}
+	for name, s := range parts {
+		if name == "/[Content_Types].xml" {
+			continue
+		}
+		if !known[name] && !known[path.Ext(name)] {
+			t.Errorf("%s has no content type", name)
+		}
+		if !strings.HasSuffix(name, ".rels") {
+			continue
+		}
+		checkXML(t, name, s)
+		var rels struct {
+			Relationship []struct {
+				Target string `xml:",attr"`
+			}
+		}
+		if err := xml.Unmarshal([]byte(s), &rels); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, r := range rels.Relationship {
+			if _, ok := parts[r.Target]; !ok {
+				t.Errorf("%s: target %s does not exist", name, r.Target)
+			}
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, &ticket.Ticket{Copies: 2, Duplex: ticket.TwoSidedLongEdge})
+	w.Title = "Report & summary"
+	font, err := w.AddFont(testFont)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := image.NewGray(image.Rect(0, 0, 4, 2))
+	img, err := w.AddImage(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jb bytes.Buffer
+	if err := jpeg.Encode(&jb, m, nil); err != nil {
+		t.Fatal(err)
+	}
+	jimg, err := w.AddJPEG(jb.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := w.NewPage(816, 1056)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Text(10, 20, "no font"); err != ErrNoFont {
+		t.Errorf("Text without font: got %v, want %v", err, ErrNoFont)
+	}
+	if err := p.SetFont(font, 12); err != nil {
+		t.Fatal(err)
+	}
+	p.SetFillColor(color.RGBA{0xff, 0, 0, 0xff})
+	if err := p.Text(10, 20, "{a} <b> & \"c\"\n"); err != nil {
+		t.Fatal(err)
+	}
+	p.SetLineWidth(0.5)
+	p.SetStrokeColor(color.NRGBA{0, 0, 0xff, 0x80})
+	p.Line(0, 0, 100, 50.25)
+	p.StrokeRect(10, 10, 20, 30)
+	p.FillRect(1, 2, 3, 4)
+	if err := p.DrawImage(img, 100, 200, 40, 20); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.DrawImage(img, 0, 0, 4, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = w.NewPage(400, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.DrawImage(jimg, 0, 0, 400, 300); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	names, parts := readParts(t, b.Bytes())
+	if names[0] != "FixedDocumentSequence.fdseq" {
+		t.Errorf("first part is %s, want FixedDocumentSequence.fdseq", names[0])
+	}
+	checkPackage(t, parts)
+	for name, s := range parts {
+		if path.Ext(name) != ".png" && path.Ext(name) != ".jpg" && path.Ext(name) != ".ttf" {
+			checkXML(t, name, s)
+		}
+	}
+	if got := parts["/Documents/1/Resources/Fonts/1.ttf"]; got != string(testFont) {
+		t.Errorf("font data %q, want %q", got, testFont)
+	}
+
+	page := parts["/Documents/1/Pages/1.fpage"]
+	for _, want := range []string{
+		`<FixedPage xmlns="http://schemas.openxps.org/oxps/v1.0" Width="816" Height="1056" xml:lang="und">`,
+		`<Glyphs FontUri="/Documents/1/Resources/Fonts/1.ttf" FontRenderingEmSize="12" OriginX="10" OriginY="20" UnicodeString="{}{a} &lt;b&gt; &amp; &#34;c&#34;" Fill="#FF0000"/>`,
+		`<Path Data="M 0,0 L 100,50.25" Stroke="#800000FF" StrokeThickness="0.5"/>`,
+		`<Path Data="M 10,10 L 30,10 30,40 10,40 Z" Stroke="#800000FF" StrokeThickness="0.5"/>`,
+		`<Path Data="M 1,2 L 4,2 4,6 1,6 Z" Fill="#FF0000"/>`,
+		`<ImageBrush ImageSource="/Documents/1/Resources/Images/1.png" Viewbox="0,0,4,2" ViewboxUnits="Absolute" Viewport="100,200,40,20" ViewportUnits="Absolute"/>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page 1 does not contain %s", want)
+		}
+	}
+	// Image used twice is listed once.
+	if n := strings.Count(parts["/Documents/1/Pages/_rels/1.fpage.rels"], "<Relationship "); n != 2 {
+		t.Errorf("page 1 has %d resources, want 2", n)
+	}
+	if !strings.Contains(parts["/Documents/1/Pages/_rels/2.fpage.rels"], `Target="/Documents/1/Resources/Images/2.jpg"`) {
+		t.Errorf("page 2 does not use JPEG image")
+	}
+	var doc struct {
+		PageContent []struct {
+			Source string `xml:",attr"`
+			Width  string `xml:",attr"`
+		}
+	}
+	if err := xml.Unmarshal([]byte(parts["/Documents/1/FixedDocument.fdoc"]), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.PageContent) != 2 || doc.PageContent[1].Source != "/Documents/1/Pages/2.fpage" || doc.PageContent[1].Width != "400" {
+		t.Errorf("bad fixed document: %+v", doc)
+	}
+	if !strings.Contains(parts["/docProps/core.xml"], "<dc:title>Report &amp; summary</dc:title>") {
+		t.Errorf("title is not stored: %s", parts["/docProps/core.xml"])
+	}
+	if !strings.Contains(parts["/_rels/FixedDocumentSequence.fdseq.rels"], `Target="/Metadata/Job_PT.xml"`) {
+		t.Errorf("PrintTicket is not attached to document sequence")
+	}
+}
+
+func TestWriterMinimal(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, nil)
+	if err := w.Close(); err != ErrNoPage {
+		t.Errorf("Close without pages: got %v, want %v", err, ErrNoPage)
+	}
+
+	b.Reset()
+	w = NewWriter(&b, nil)
+	if _, err := w.NewPage(100, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	names, parts := readParts(t, b.Bytes())
+	want := []string{
+		"FixedDocumentSequence.fdseq",
+		"Documents/1/Pages/1.fpage",
+		"Documents/1/FixedDocument.fdoc",
+		"_rels/.rels",
+		"[Content_Types].xml",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got parts %q, want %q", names, want)
+	}
+	checkPackage(t, parts)
+	if err := w.Close(); err != ErrClosed {
+		t.Errorf("second Close: got %v, want %v", err, ErrClosed)
+	}
+	if _, err := w.NewPage(100, 100); err != ErrClosed {
+		t.Errorf("NewPage after Close: got %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	w1, w2 := NewWriter(ioutil.Discard, nil), NewWriter(ioutil.Discard, nil)
+	if _, err := w1.AddFont([]byte("ttcf\x00\x01\x00\x00\x00\x00\x00\x01")); err != ErrBadFont {
+		t.Errorf("font collection: got %v, want %v", err, ErrBadFont)
+	}
+	if _, err := w1.AddJPEG([]byte("\xff\xd8\xff\xd9")); err != ErrBadJPEG {
+		t.Errorf("bad JPEG: got %v, want %v", err, ErrBadJPEG)
+	}
+	font, err := w1.AddFont(testFont)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := w1.AddImage(image.NewGray(image.Rect(0, 0, 1, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := w2.NewPage(100, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SetFont(font, 10); err != ErrForeignFont {
+		t.Errorf("SetFont: got %v, want %v", err, ErrForeignFont)
+	}
+	if err := p.DrawImage(img, 0, 0, 1, 1); err != ErrForeignImage {
+		t.Errorf("DrawImage: got %v, want %v", err, ErrForeignImage)
+	}
+}
+
+func TestJFIFDensity(t *testing.T) {
+	jfif := func(units byte, x, y int) []byte {
+		return []byte{0xff, 0xd8, 0xff, 0xe0, 0, 16, 'J', 'F', 'I', 'F', 0, 1, 1, units,
+			byte(x >> 8), byte(x), byte(y >> 8), byte(y)}
+	}
+	tests := []struct {
+		data []byte
+		x, y float64
+	}{
+		{jfif(1, 300, 600), 300, 600},
+		{jfif(2, 100, 100), 254, 254},
+		{jfif(0, 1, 1), 96, 96},
+		{jfif(1, 0, 0), 96, 96},
+		{[]byte{0xff, 0xd8, 0xff, 0xdb}, 96, 96},
+	}
+	for _, tt := range tests {
+		if x, y := jfifDensity(tt.data); x != tt.x || y != tt.y {
+			t.Errorf("%x: got %v, %v, want %v, %v", tt.data, x, y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestPrintTicket(t *testing.T) {
+	pt := string(PrintTicket(&ticket.Ticket{
+		Copies:     3,
+		Collate:    true,
+		Duplex:     ticket.TwoSidedShortEdge,
+		Media:      media.A4,
+		Tray:       "manual",
+		Resolution: 600,
+		Color:      ticket.Monochrome,
+		Staple:     true,
+	}))
+	checkXML(t, "PrintTicket", pt)
+	for _, want := range []string{
+		`<psf:ParameterInit name="psk:JobCopiesAllDocuments"><psf:Value xsi:type="xsd:integer">3</psf:Value></psf:ParameterInit>`,
+		`<psf:Feature name="psk:DocumentCollate"><psf:Option name="psk:Collated"/></psf:Feature>`,
+		`<psf:Feature name="psk:JobDuplexAllDocumentsContiguously"><psf:Option name="psk:TwoSidedShortEdge"/></psf:Feature>`,
+		`<psf:Feature name="psk:PageMediaSize"><psf:Option name="psk:ISOA4"><psf:ScoredProperty name="psk:MediaSizeWidth"><psf:Value xsi:type="xsd:integer">210000</psf:Value></psf:ScoredProperty>`,
+		`<psf:Feature name="psk:JobInputBin"><psf:Option name="psk:Manual"/></psf:Feature>`,
+		`<psf:ScoredProperty name="psk:ResolutionY"><psf:Value xsi:type="xsd:integer">600</psf:Value></psf:ScoredProperty>`,
+		`<psf:Feature name="psk:PageOutputColor"><psf:Option name="psk:Monochrome"/></psf:Feature>`,
+		`<psf:Feature name="psk:JobStapleAllDocuments"><psf:Option name="psk:StapleTopLeft"/></psf:Feature>`,
+	} {
+		if !strings.Contains(pt, want) {
+			t.Errorf("PrintTicket does not contain %s", want)
+		}
+	}
+	pt = string(PrintTicket(nil))
+	checkXML(t, "PrintTicket", pt)
+	if strings.Contains(pt, "psf:Feature") || strings.Contains(pt, "psf:ParameterInit") {
+		t.Errorf("nil ticket sets options:\n%s", pt)
+	}
+	pt = string(PrintTicket(&ticket.Ticket{Tray: "tray-2", Media: media.Size{Width: 10000, Height: 15000}}))
+	checkXML(t, "PrintTicket", pt)
+	if strings.Contains(pt, "JobInputBin") {
+		t.Errorf("tray-2 must be ignored")
+	}
+	if !strings.Contains(pt, `<psf:Feature name="psk:PageMediaSize"><psf:Option><psf:ScoredProperty name="psk:MediaSizeWidth"><psf:Value xsi:type="xsd:integer">100000</psf:Value>`) {
+		t.Errorf("custom media size is not set:\n%s", pt)
+	}
+}
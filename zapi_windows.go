@@ -0,0 +1,367 @@
+// MACHINE GENERATED BY 'go generate' COMMAND; DO NOT EDIT
+
+package printer
+
+import "unsafe"
+import "syscall"
+
+var _ unsafe.Pointer
+
+var (
+	modwinspool = syscall.NewLazyDLL("winspool.drv")
+
+	procGetDefaultPrinterW                 = modwinspool.NewProc("GetDefaultPrinterW")
+	procSetDefaultPrinterW                 = modwinspool.NewProc("SetDefaultPrinterW")
+	procClosePrinter                       = modwinspool.NewProc("ClosePrinter")
+	procOpenPrinterW                       = modwinspool.NewProc("OpenPrinterW")
+	procStartDocPrinterW                   = modwinspool.NewProc("StartDocPrinterW")
+	procEndDocPrinter                      = modwinspool.NewProc("EndDocPrinter")
+	procWritePrinter                       = modwinspool.NewProc("WritePrinter")
+	procStartPagePrinter                   = modwinspool.NewProc("StartPagePrinter")
+	procEndPagePrinter                     = modwinspool.NewProc("EndPagePrinter")
+	procEnumPrintersW                      = modwinspool.NewProc("EnumPrintersW")
+	procGetPrinterDriverW                  = modwinspool.NewProc("GetPrinterDriverW")
+	procEnumJobsW                          = modwinspool.NewProc("EnumJobsW")
+	procGetJobW                            = modwinspool.NewProc("GetJobW")
+	procEnumFormsW                         = modwinspool.NewProc("EnumFormsW")
+	procEnumPortsW                         = modwinspool.NewProc("EnumPortsW")
+	procEnumMonitorsW                      = modwinspool.NewProc("EnumMonitorsW")
+	procAddPrinterW                        = modwinspool.NewProc("AddPrinterW")
+	procDeletePrinter                      = modwinspool.NewProc("DeletePrinter")
+	procAddPrinterConnectionW              = modwinspool.NewProc("AddPrinterConnectionW")
+	procDeletePrinterConnectionW           = modwinspool.NewProc("DeletePrinterConnectionW")
+	procGetPrinterW                        = modwinspool.NewProc("GetPrinterW")
+	procSetPrinterW                        = modwinspool.NewProc("SetPrinterW")
+	procFindFirstPrinterChangeNotification = modwinspool.NewProc("FindFirstPrinterChangeNotification")
+	procFindNextPrinterChangeNotification  = modwinspool.NewProc("FindNextPrinterChangeNotification")
+	procFindClosePrinterChangeNotification = modwinspool.NewProc("FindClosePrinterChangeNotification")
+	procAbortPrinter                       = modwinspool.NewProc("AbortPrinter")
+	procReadPrinter                        = modwinspool.NewProc("ReadPrinter")
+)
+
+func GetDefaultPrinter(buf *uint16, bufN *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall(procGetDefaultPrinterW.Addr(), 2, uintptr(unsafe.Pointer(buf)), uintptr(unsafe.Pointer(bufN)), 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func SetDefaultPrinter(name *uint16) (err error) {
+	r1, _, e1 := syscall.Syscall(procSetDefaultPrinterW.Addr(), 1, uintptr(unsafe.Pointer(name)), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func ClosePrinter(h syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procClosePrinter.Addr(), 1, uintptr(h), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func OpenPrinter(name *uint16, h *syscall.Handle, defaults uintptr) (err error) {
+	r1, _, e1 := syscall.Syscall(procOpenPrinterW.Addr(), 3, uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(h)), uintptr(defaults))
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func StartDocPrinter(h syscall.Handle, level uint32, docinfo *DOC_INFO_1) (jobID uint32, err error) {
+	r0, _, e1 := syscall.Syscall(procStartDocPrinterW.Addr(), 3, uintptr(h), uintptr(level), uintptr(unsafe.Pointer(docinfo)))
+	jobID = uint32(r0)
+	if jobID == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func EndDocPrinter(h syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procEndDocPrinter.Addr(), 1, uintptr(h), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func WritePrinter(h syscall.Handle, buf *byte, bufN uint32, written *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procWritePrinter.Addr(), 4, uintptr(h), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(written)), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func StartPagePrinter(h syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procStartPagePrinter.Addr(), 1, uintptr(h), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func EndPagePrinter(h syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procEndPagePrinter.Addr(), 1, uintptr(h), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func EnumPrinters(flags uint32, name *uint16, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall9(procEnumPrintersW.Addr(), 7, uintptr(flags), uintptr(unsafe.Pointer(name)), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(needed)), uintptr(unsafe.Pointer(returned)), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func GetPrinterDriver(h syscall.Handle, env *uint16, level uint32, di *byte, n uint32, needed *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procGetPrinterDriverW.Addr(), 6, uintptr(h), uintptr(unsafe.Pointer(env)), uintptr(level), uintptr(unsafe.Pointer(di)), uintptr(n), uintptr(unsafe.Pointer(needed)))
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func EnumJobs(h syscall.Handle, firstJob uint32, noJobs uint32, level uint32, buf *byte, bufN uint32, bytesNeeded *uint32, jobsReturned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall9(procEnumJobsW.Addr(), 8, uintptr(h), uintptr(firstJob), uintptr(noJobs), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(bytesNeeded)), uintptr(unsafe.Pointer(jobsReturned)), 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func GetJob(h syscall.Handle, jobID uint32, level uint32, buf *byte, bufN uint32, needed *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procGetJobW.Addr(), 6, uintptr(h), uintptr(jobID), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(needed)))
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func EnumForms(h syscall.Handle, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procEnumFormsW.Addr(), 6, uintptr(h), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(needed)), uintptr(unsafe.Pointer(returned)))
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func EnumPorts(name *uint16, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procEnumPortsW.Addr(), 6, uintptr(unsafe.Pointer(name)), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(needed)), uintptr(unsafe.Pointer(returned)))
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func EnumMonitors(name *uint16, level uint32, buf *byte, bufN uint32, needed *uint32, returned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procEnumMonitorsW.Addr(), 6, uintptr(unsafe.Pointer(name)), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(needed)), uintptr(unsafe.Pointer(returned)))
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func addPrinter(server *uint16, level uint32, pi *PRINTER_INFO_2) (h syscall.Handle, err error) {
+	r0, _, e1 := syscall.Syscall(procAddPrinterW.Addr(), 3, uintptr(unsafe.Pointer(server)), uintptr(level), uintptr(unsafe.Pointer(pi)))
+	h = syscall.Handle(r0)
+	if h == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func deletePrinter(h syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procDeletePrinter.Addr(), 1, uintptr(h), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func AddPrinterConnection(name *uint16) (err error) {
+	r1, _, e1 := syscall.Syscall(procAddPrinterConnectionW.Addr(), 1, uintptr(unsafe.Pointer(name)), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func DeletePrinterConnection(name *uint16) (err error) {
+	r1, _, e1 := syscall.Syscall(procDeletePrinterConnectionW.Addr(), 1, uintptr(unsafe.Pointer(name)), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func GetPrinter(h syscall.Handle, level uint32, buf *byte, bufN uint32, needed *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procGetPrinterW.Addr(), 5, uintptr(h), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(needed)), 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func SetPrinter(h syscall.Handle, level uint32, buf *byte, command uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procSetPrinterW.Addr(), 4, uintptr(h), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(command), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func FindFirstPrinterChangeNotification(h syscall.Handle, filter uint32, options uint32, notifyOptions uintptr) (change syscall.Handle, err error) {
+	r0, _, e1 := syscall.Syscall6(procFindFirstPrinterChangeNotification.Addr(), 4, uintptr(h), uintptr(filter), uintptr(options), uintptr(notifyOptions), 0, 0)
+	change = syscall.Handle(r0)
+	if change == syscall.InvalidHandle {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func FindNextPrinterChangeNotification(change syscall.Handle, cause *uint32, notifyOptions uintptr, notifyInfo uintptr) (err error) {
+	r1, _, e1 := syscall.Syscall6(procFindNextPrinterChangeNotification.Addr(), 4, uintptr(change), uintptr(unsafe.Pointer(cause)), uintptr(notifyOptions), uintptr(notifyInfo), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func FindClosePrinterChangeNotification(change syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procFindClosePrinterChangeNotification.Addr(), 1, uintptr(change), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func AbortPrinter(h syscall.Handle) (err error) {
+	r1, _, e1 := syscall.Syscall(procAbortPrinter.Addr(), 1, uintptr(h), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
+
+func ReadPrinter(h syscall.Handle, buf *byte, bufN uint32, read *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procReadPrinter.Addr(), 4, uintptr(h), uintptr(unsafe.Pointer(buf)), uintptr(bufN), uintptr(unsafe.Pointer(read)), 0, 0)
+	if r1 == 0 {
+		if e1 != 0 {
+			err = error(e1)
+		} else {
+			err = syscall.EINVAL
+		}
+	}
+	return
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zpl
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+	"text/template/parse"
+)
+
+// Template is ZPL label written in text/template syntax, like
+//
+//	^XA^CI28^FO50,50^A0N,40,40^FH^FD{{.Name}}^FS^XZ
+//
+// Output of every action is escaped with Escape, so data cannot
+// inject ZPL commands. Fields that print template data must use ^FH.
+type Template struct {
+	t *template.Template
+}
+
+// escapeFunc is name of template function that escapes action output.
+const escapeFunc = "zplEscape"
+
+// ParseTemplate parses text as template called name.
+func ParseTemplate(name, text string) (*Template, error) {
+	t, err := template.New(name).Funcs(template.FuncMap{
+		escapeFunc: func(v interface{}) string {
+			return Escape(fmt.Sprint(v))
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, tt := range t.Templates() {
+		if tt.Tree != nil {
+			escapeList(tt.Tree, tt.Tree.Root)
+		}
+	}
+	return &Template{t: t}, nil
+}
+
+// escapeList adds escaping to all actions of list.
+func escapeList(tree *parse.Tree, list *parse.ListNode) {
+	if list == nil {
+		return
+	}
+	for _, n := range list.Nodes {
+		switch n := n.(type) {
+		case *parse.ActionNode:
+			// Actions that declare variables print nothing.
+			if len(n.Pipe.Decl) == 0 {
+				id := parse.NewIdentifier(escapeFunc).SetTree(tree).SetPos(n.Pos)
+				n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+					NodeType: parse.NodeCommand,
+					Pos:      n.Pos,
+					Args:     []parse.Node{id},
+				})
+			}
+		case *parse.IfNode:
+			escapeList(tree, n.List)
+			escapeList(tree, n.ElseList)
+		case *parse.RangeNode:
+			escapeList(tree, n.List)
+			escapeList(tree, n.ElseList)
+		case *parse.WithNode:
+			escapeList(tree, n.List)
+			escapeList(tree, n.ElseList)
+		}
+	}
+}
+
+// Execute applies template to data and writes the output to w.
+func (t *Template) Execute(w io.Writer, data interface{}) error {
+	return t.t.Execute(w, data)
+}
@@ -0,0 +1,284 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package zpl builds ZPL II labels for Zebra and compatible label
+// printers. The result can be sent to the printer as raw data, for
+// example with printer StartRawDocument and Write.
+//
+// All positions and sizes are in printer dots.
+package zpl
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+// Orientation is field rotation.
+type Orientation byte
+
+const (
+	Normal   Orientation = 'N'
+	Rotated  Orientation = 'R' // 90 degrees clockwise
+	Inverted Orientation = 'I' // 180 degrees
+	Bottom   Orientation = 'B' // 270 degrees, read from bottom up
+)
+
+// QRLevel is QR code error correction level.
+type QRLevel byte
+
+const (
+	QRLevelH QRLevel = 'H' // ultra-high reliability
+	QRLevelQ QRLevel = 'Q' // high reliability
+	QRLevelM QRLevel = 'M' // standard
+	QRLevelL QRLevel = 'L' // high density
+)
+
+// Escape returns s escaped for use as field data of a field with
+// ^FH command: '^', '~' and '_' are replaced with hexadecimal escapes.
+func Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '^', '~', '_':
+			fmt.Fprintf(&b, "_%02X", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// Builder builds ZPL commands. Builder methods append commands
+// to the stream, and the result is returned by Bytes or written
+// by WriteTo. Every label must be started with Start and
+// finished with End.
+type Builder struct {
+	buf bytes.Buffer
+}
+
+// New returns new empty Builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Bytes returns commands built so far.
+func (b *Builder) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// WriteTo writes commands built so far to w.
+func (b *Builder) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.buf.Bytes())
+	return int64(n), err
+}
+
+// Raw appends s to the stream as is.
+func (b *Builder) Raw(s string) {
+	b.buf.WriteString(s)
+}
+
+// Start starts new label (^XA). Field data is UTF-8 encoded (^CI28).
+func (b *Builder) Start() {
+	b.buf.WriteString("^XA^CI28")
+}
+
+// End finishes the label and prints it (^XZ).
+func (b *Builder) End() {
+	b.buf.WriteString("^XZ")
+}
+
+// Quantity sets number of label copies printed (^PQ).
+func (b *Builder) Quantity(n int) {
+	fmt.Fprintf(&b.buf, "^PQ%d", n)
+}
+
+// LabelSize sets label width (^PW) and length (^LL).
+func (b *Builder) LabelSize(width, length int) {
+	fmt.Fprintf(&b.buf, "^PW%d^LL%d", width, length)
+}
+
+// SetFont sets font used by the following text fields (^CF).
+// font is font name, like '0' for scalable font.
+func (b *Builder) SetFont(font byte, height, width int) {
+	fmt.Fprintf(&b.buf, "^CF%c,%d,%d", font, height, width)
+}
+
+// SetOrientation sets orientation of the following fields (^FW).
+func (b *Builder) SetOrientation(o Orientation) {
+	fmt.Fprintf(&b.buf, "^FW%c", o)
+}
+
+// SetBarcode sets default module width, wide to narrow bar ratio
+// and height of the following barcodes (^BY).
+func (b *Builder) SetBarcode(moduleWidth int, ratio float64, height int) {
+	fmt.Fprintf(&b.buf, "^BY%d,%.1f,%d", moduleWidth, ratio, height)
+}
+
+// origin appends field origin (^FO).
+func (b *Builder) origin(x, y int) {
+	fmt.Fprintf(&b.buf, "^FO%d,%d", x, y)
+}
+
+// data appends field data followed by field separator.
+func (b *Builder) data(s string) {
+	b.buf.WriteString("^FH^FD")
+	b.buf.WriteString(Escape(s))
+	b.buf.WriteString("^FS")
+}
+
+// Text prints text s at x, y with current font.
+func (b *Builder) Text(x, y int, s string) {
+	b.origin(x, y)
+	b.data(s)
+}
+
+// TextBlock prints text s wrapped into block of width dots and
+// at most lines lines at x, y with current font (^FB).
+func (b *Builder) TextBlock(x, y, width, lines int, s string) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^FB%d,%d", width, lines)
+	b.data(s)
+}
+
+// Box draws rectangle with border thickness dots thick (^GB).
+// Thickness equal to width or height draws filled rectangle.
+func (b *Builder) Box(x, y, width, height, thickness int) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^GB%d,%d,%d^FS", width, height, thickness)
+}
+
+func yesNo(v bool) byte {
+	if v {
+		return 'Y'
+	}
+	return 'N'
+}
+
+// Code128 prints Code 128 barcode of the given height (^BC).
+// Height 0 uses height set by SetBarcode. hri prints human
+// readable text below the barcode.
+func (b *Builder) Code128(x, y int, data string, height int, hri bool) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^BCN,%s,%c,N,N", optional(height), yesNo(hri))
+	b.data(data)
+}
+
+// Code39 prints Code 39 barcode of the given height (^B3).
+// Height 0 uses height set by SetBarcode.
+func (b *Builder) Code39(x, y int, data string, height int, hri bool) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^B3N,N,%s,%c,N", optional(height), yesNo(hri))
+	b.data(data)
+}
+
+// EAN13 prints EAN-13 barcode of the given height (^BE).
+// Height 0 uses height set by SetBarcode.
+func (b *Builder) EAN13(x, y int, data string, height int, hri bool) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^BEN,%s,%c,N", optional(height), yesNo(hri))
+	b.data(data)
+}
+
+// QR prints QR code model 2 with module size of magnification dots (^BQ).
+func (b *Builder) QR(x, y int, data string, magnification int, level QRLevel) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^BQN,2,%d", magnification)
+	// Data starts with error correction level and automatic
+	// data input mode.
+	b.data(string(level) + "A," + data)
+}
+
+// DataMatrix prints DataMatrix (ECC 200) barcode with module size
+// of moduleSize dots (^BX).
+func (b *Builder) DataMatrix(x, y int, data string, moduleSize int) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^BXN,%d,200", moduleSize)
+	b.data(data)
+}
+
+// optional formats n, or returns empty string (default value) if n is 0.
+func optional(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
+
+// Image prints image m converted to black and white with dither
+// method d at x, y. Image is printed one pixel per dot.
+func (b *Builder) Image(x, y int, m image.Image, d bitmap.Dither) {
+	b.Bitmap(x, y, bitmap.FromImage(m, d))
+}
+
+// Bitmap prints bitmap bm at x, y (^GF). Bitmap data is sent
+// compressed in Z64 encoding.
+func (b *Builder) Bitmap(x, y int, bm *bitmap.Bitmap) {
+	b.origin(x, y)
+	n := bm.Stride * bm.Height
+	fmt.Fprintf(&b.buf, "^GFA,%d,%d,%d,%s^FS", n, n, bm.Stride, z64(bm.Pix))
+}
+
+// z64 returns data encoded in ZPL Z64 encoding: zlib compressed
+// and base64 encoded, followed by CRC of base64 text.
+func z64(data []byte) string {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	s := base64.StdEncoding.EncodeToString(buf.Bytes())
+	return fmt.Sprintf(":Z64:%s:%04X", s, crc16([]byte(s)))
+}
+
+// crc16 returns CRC-16/XMODEM checksum of data.
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, c := range data {
+		crc ^= uint16(c) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// StoreFormat starts label format that is stored in printer memory
+// under name, like "R:LABEL.ZPL", instead of being printed (^DF).
+// Variable fields of the format are added with Field, and the format
+// is printed with RecallFormat.
+func (b *Builder) StoreFormat(name string) {
+	fmt.Fprintf(&b.buf, "^DF%s^FS", name)
+}
+
+// Field adds variable field number n at x, y to stored format (^FN).
+func (b *Builder) Field(x, y, n int) {
+	b.origin(x, y)
+	fmt.Fprintf(&b.buf, "^FN%d^FS", n)
+}
+
+// RecallFormat prints format stored under name with field
+// numbers replaced by values of fields (^XF).
+func (b *Builder) RecallFormat(name string, fields map[int]string) {
+	fmt.Fprintf(&b.buf, "^XF%s^FS", name)
+	nums := make([]int, 0, len(fields))
+	for n := range fields {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	for _, n := range nums {
+		fmt.Fprintf(&b.buf, "^FN%d", n)
+		b.data(fields[n])
+	}
+}
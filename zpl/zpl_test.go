@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zpl
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/alexbrainman/printer/bitmap"
+)
+
+func TestBuilder(t *testing.T) {
+	b := New()
+	b.Start()
+	b.LabelSize(812, 1218)
+	b.SetFont('0', 40, 30)
+	b.Text(50, 60, "Ship to: 5^ ~A_")
+	b.TextBlock(50, 120, 700, 3, "long text")
+	b.Box(10, 10, 792, 1198, 4)
+	b.SetBarcode(2, 3, 100)
+	b.Code128(50, 300, "ABC123", 0, true)
+	b.Code39(50, 450, "XYZ", 80, false)
+	b.EAN13(50, 550, "400638133393", 0, true)
+	b.QR(500, 300, "https://example.com", 5, QRLevelM)
+	b.DataMatrix(500, 600, "dm", 8)
+	b.SetOrientation(Rotated)
+	b.Quantity(2)
+	b.End()
+	want := "^XA^CI28^PW812^LL1218^CF0,40,30" +
+		"^FO50,60^FH^FDShip to: 5_5E _7EA_5F^FS" +
+		"^FO50,120^FB700,3^FH^FDlong text^FS" +
+		"^FO10,10^GB792,1198,4^FS" +
+		"^BY2,3.0,100" +
+		"^FO50,300^BCN,,Y,N,N^FH^FDABC123^FS" +
+		"^FO50,450^B3N,N,80,N,N^FH^FDXYZ^FS" +
+		"^FO50,550^BEN,,Y,N^FH^FD400638133393^FS" +
+		"^FO500,300^BQN,2,5^FH^FDMA,https://example.com^FS" +
+		"^FO500,600^BXN,8,200^FH^FDdm^FS" +
+		"^FWR^PQ2^XZ"
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("unexpected ZPL:\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestStoredFormat(t *testing.T) {
+	b := New()
+	b.Start()
+	b.StoreFormat("R:SHIP.ZPL")
+	b.Field(10, 20, 1)
+	b.Field(10, 80, 2)
+	b.End()
+	b.Start()
+	b.RecallFormat("R:SHIP.ZPL", map[int]string{2: "b^", 1: "a"})
+	b.End()
+	want := "^XA^CI28^DFR:SHIP.ZPL^FS^FO10,20^FN1^FS^FO10,80^FN2^FS^XZ" +
+		"^XA^CI28^XFR:SHIP.ZPL^FS^FN1^FH^FDa^FS^FN2^FH^FDb_5E^FS^XZ"
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("unexpected ZPL:\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCRC16(t *testing.T) {
+	// CRC-16/XMODEM check value.
+	if got := crc16([]byte("123456789")); got != 0x31c3 {
+		t.Errorf("crc16 returned %04x, want 31c3", got)
+	}
+}
+
+func TestBitmap(t *testing.T) {
+	bm := bitmap.New(20, 30)
+	bm.Set(0, 0, true)
+	bm.Set(19, 29, true)
+	b := New()
+	b.Bitmap(5, 6, bm)
+	m := regexp.MustCompile(`^\^FO5,6\^GFA,90,90,3,:Z64:([A-Za-z0-9+/=]+):([0-9A-F]{4})\^FS$`).FindStringSubmatch(string(b.Bytes()))
+	if m == nil {
+		t.Fatalf("unexpected ^GF command: %s", b.Bytes())
+	}
+	if crc := fmt.Sprintf("%04X", crc16([]byte(m[1]))); crc != m[2] {
+		t.Errorf("Z64 CRC is %s, want %s", m[2], crc)
+	}
+	z, err := base64.StdEncoding.DecodeString(m[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := zlib.NewReader(bytes.NewReader(z))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, bm.Pix) {
+		t.Errorf("Z64 data is %x, want %x", data, bm.Pix)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate("label", `^XA^FO10,10^FH^FD{{.Name}}^FS`+
+		`{{range .Items}}^FO10,{{.Y}}^FH^FD{{.Text}} x{{.N}}^FS{{end}}`+
+		`{{with $x := .Name}}{{end}}{{if .Code}}^FO1,1^BQN,2,4^FH^FDMA,{{.Code}}^FS{{end}}^XZ`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type item struct {
+		Y    int
+		Text string
+		N    int
+	}
+	data := struct {
+		Name  string
+		Items []item
+		Code  string
+	}{
+		Name:  "^XZ^XA evil",
+		Items: []item{{100, "tea_bag", 2}, {150, "cup~", 1}},
+		Code:  "x^y",
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		t.Fatal(err)
+	}
+	want := "^XA^FO10,10^FH^FD_5EXZ_5EXA evil^FS" +
+		"^FO10,100^FH^FDtea_5Fbag x2^FS^FO10,150^FH^FDcup_7E x1^FS" +
+		"^FO1,1^BQN,2,4^FH^FDMA,x_5Ey^FS^XZ"
+	if got := b.String(); got != want {
+		t.Errorf("unexpected template output:\n%s\nwant\n%s", got, want)
+	}
+	if _, err := ParseTemplate("bad", "{{.Name"); err == nil {
+		t.Error("parsing bad template succeeded")
+	}
+}